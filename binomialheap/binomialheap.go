@@ -0,0 +1,262 @@
+package binomialheap
+
+import "fmt"
+
+// node is a vertex of a binomial tree. The children of a node are kept in a
+// singly linked list ordered by decreasing degree.
+type node struct {
+	value   int
+	degree  int
+	parent  *node
+	child   *node
+	sibling *node
+}
+
+// BinomialHeap is a forest of binomial trees, at most one for every degree,
+// each of which satisfies the min-heap property. It removes the element with
+// the least value first.
+type BinomialHeap struct {
+	// head is the first root of the forest; roots are ordered by increasing
+	// degree.
+	head *node
+	min  *node
+	size int
+}
+
+// New returns a new BinomialHeap instance.
+func New() BinomialHeap {
+	return BinomialHeap{head: nil, min: nil, size: 0}
+}
+
+// Size returns the number of elements that are into the heap.
+//
+// Complexity: O(1)
+func (h *BinomialHeap) Size() int {
+	return h.size
+}
+
+// IsEmpty returns whether the heap is empty or not.
+//
+// Complexity: O(1)
+func (h *BinomialHeap) IsEmpty() bool {
+	return h.Size() == 0
+}
+
+// Add adds a new element with the specified `value` to the heap.
+//
+// Complexity: O(log(n)) in the worst case, O(1) amortized
+func (h *BinomialHeap) Add(value int) {
+	// Like incrementing a binary counter: the new tree is linked with the
+	// first roots as long as they have the same degree.
+	carry := &node{value: value}
+	for h.head != nil && h.head.degree == carry.degree {
+		next := h.head.sibling
+		h.head.sibling = nil
+		carry = link(carry, h.head)
+		h.head = next
+	}
+	carry.sibling = h.head
+	h.head = carry
+
+	// The minimum may have been linked below `carry`, but in that case the
+	// root of `carry` is not greater than it.
+	if h.min == nil || carry.value <= h.min.value {
+		h.min = carry
+	}
+	h.size++
+}
+
+// Peek returns the value of the next element that would be returned by
+// RemoveMin.
+//
+// Complexity: O(1)
+func (h *BinomialHeap) Peek() int {
+	if h.IsEmpty() {
+		panic("Peek: cannot peek from an empty heap")
+	}
+	return h.min.value
+}
+
+// RemoveMin removes the element with the least value from the heap and
+// returns its value.
+//
+// Complexity: O(log(n))
+func (h *BinomialHeap) RemoveMin() int {
+	if h.IsEmpty() {
+		panic("RemoveMin: cannot remove from an empty heap")
+	}
+	res := h.min.value
+	h.removeRoot(h.min)
+	return res
+}
+
+// Union moves all the elements of `other` into the heap, leaving `other`
+// empty.
+//
+// Complexity: O(log(n))
+func (h *BinomialHeap) Union(other *BinomialHeap) {
+	h.head = union(h.head, other.head)
+	h.size += other.size
+	h.updateMin()
+	other.head, other.min, other.size = nil, nil, 0
+}
+
+// Contains returns whether the heap contains the specified `value` or not.
+//
+// Complexity: O(n)
+func (h *BinomialHeap) Contains(value int) bool {
+	return find(h.head, value) != nil
+}
+
+// RemoveFirstOccurrence removes an occurrence of the specified `value`.
+//
+// Complexity: O(n)
+func (h *BinomialHeap) RemoveFirstOccurrence(value int) error {
+	n := find(h.head, value)
+	if n == nil {
+		return fmt.Errorf("cannot remove value %d not in heap", value)
+	}
+
+	// Move the value up to the root of its tree, as if it were decreased to
+	// minus infinity, then remove that root.
+	for n.parent != nil {
+		n.value, n.parent.value = n.parent.value, n.value
+		n = n.parent
+	}
+	h.removeRoot(n)
+	return nil
+}
+
+// removeRoot removes the root `r` from the forest and merges its children
+// back into the heap.
+func (h *BinomialHeap) removeRoot(r *node) {
+	// Detach the root from the root list.
+	if h.head == r {
+		h.head = r.sibling
+	} else {
+		prev := h.head
+		for prev.sibling != r {
+			prev = prev.sibling
+		}
+		prev.sibling = r.sibling
+	}
+
+	// The children are ordered by decreasing degree, so reverse them to get
+	// a valid root list.
+	var children *node
+	for c := r.child; c != nil; {
+		next := c.sibling
+		c.parent = nil
+		c.sibling = children
+		children = c
+		c = next
+	}
+
+	h.head = union(h.head, children)
+	h.size--
+	h.updateMin()
+}
+
+func (h *BinomialHeap) updateMin() {
+	h.min = h.head
+	for r := h.head; r != nil; r = r.sibling {
+		if r.value < h.min.value {
+			h.min = r
+		}
+	}
+}
+
+// link makes the root with the greater value the first child of the other
+// one, which must have the same degree, and returns the new root.
+func link(a, b *node) *node {
+	if b.value < a.value {
+		a, b = b, a
+	}
+	b.parent = a
+	b.sibling = a.child
+	a.child = b
+	a.degree++
+	return a
+}
+
+// union merges two root lists ordered by increasing degree and links the
+// trees with the same degree, returning the new root list.
+func union(a, b *node) *node {
+	// Merge the two lists as in merge sort.
+	var head *node
+	tail := &head
+	for a != nil && b != nil {
+		if a.degree <= b.degree {
+			*tail, a = a, a.sibling
+		} else {
+			*tail, b = b, b.sibling
+		}
+		tail = &(*tail).sibling
+	}
+	if a != nil {
+		*tail = a
+	} else {
+		*tail = b
+	}
+
+	// Link adjacent roots with the same degree. At most three roots can have
+	// the same degree at any time, so when it happens the first is skipped.
+	var prev *node
+	cur := head
+	for cur != nil && cur.sibling != nil {
+		next := cur.sibling
+		if cur.degree != next.degree || (next.sibling != nil && next.sibling.degree == cur.degree) {
+			prev, cur = cur, next
+			continue
+		}
+		rest := next.sibling
+		cur.sibling, next.sibling = nil, nil
+		cur = link(cur, next)
+		cur.sibling = rest
+		if prev == nil {
+			head = cur
+		} else {
+			prev.sibling = cur
+		}
+	}
+	return head
+}
+
+func find(first *node, value int) *node {
+	for n := first; n != nil; n = n.sibling {
+		// Thanks to the heap property, a subtree whose root is greater than
+		// `value` cannot contain it.
+		if n.value == value {
+			return n
+		}
+		if n.value < value {
+			if res := find(n.child, value); res != nil {
+				return res
+			}
+		}
+	}
+	return nil
+}
+
+func (h BinomialHeap) String() string {
+	res := "[ "
+	for r := h.head; r != nil; r = r.sibling {
+		res += fmt.Sprintf("B%d: %s", r.degree, tree(r))
+		if r.sibling != nil {
+			res += "| "
+		}
+	}
+	return res + "]"
+}
+
+func tree(n *node) string {
+	res := fmt.Sprintf("%d ", n.value)
+	if n.child != nil {
+		res += "( "
+		for c := n.child; c != nil; c = c.sibling {
+			res += tree(c)
+		}
+		res += ") "
+	}
+	return res
+}
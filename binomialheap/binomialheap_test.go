@@ -0,0 +1,115 @@
+package binomialheap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/BuriedInTheGround/datastructures/priorityqueue"
+)
+
+func TestRemoveMin(t *testing.T) {
+	h := New()
+
+	h.Add(2)
+	h.Add(5)
+	h.Add(4)
+	h.Add(1)
+	h.Add(3)
+
+	if h.Size() != 5 {
+		t.Errorf("the heap should have a size of %d, but it does not", 5)
+	}
+	for i := 1; !h.IsEmpty(); i++ {
+		if v := h.RemoveMin(); v != i {
+			t.Errorf("wrong data: got %d want %d", v, i)
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	h := New()
+
+	if h.Contains(42) {
+		t.Errorf("the heap is empty, cannot contains any value")
+	}
+
+	for _, v := range []int{2, 4, 1, 3, 7, 6} {
+		h.Add(v)
+	}
+
+	if !h.Contains(6) {
+		t.Errorf("the heap must contains `%d`, but was not found", 6)
+	}
+	if h.Contains(5) {
+		t.Errorf("the heap does not contains `%d`, but was found", 5)
+	}
+	t.Log(h) // Here should be [ B1: 6 ( 7 ) | B2: 1 ( 2 ( 4 ) 3 ) ].
+}
+
+func TestUnion(t *testing.T) {
+	h1 := New()
+	h2 := New()
+
+	for i := 1; i <= 13; i++ {
+		if i%3 == 0 {
+			h1.Add(i)
+		} else {
+			h2.Add(i)
+		}
+	}
+	h1.Union(&h2)
+
+	if !h2.IsEmpty() {
+		t.Errorf("the merged heap should be empty, but it is not")
+	}
+	for i := 1; !h1.IsEmpty(); i++ {
+		if v := h1.RemoveMin(); v != i {
+			t.Errorf("wrong data: got %d want %d", v, i)
+		}
+	}
+}
+
+func TestRandomizedAgainstMinPQ(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	h := New()
+	pq := priorityqueue.New()
+
+	for step := 0; step < 5000; step++ {
+		switch op := r.Intn(10); {
+		case op < 5:
+			v := r.Intn(100)
+			h.Add(v)
+			pq.Add(v)
+		case op < 7 && !pq.IsEmpty():
+			if got, want := h.RemoveMin(), pq.RemoveMin(); got != want {
+				t.Fatalf("step %d: wrong RemoveMin: got %d want %d", step, got, want)
+			}
+		case op < 8:
+			v := r.Intn(100)
+			errH, errPQ := h.RemoveFirstOccurrence(v), pq.RemoveFirstOccurrence(v)
+			if (errH == nil) != (errPQ == nil) {
+				t.Fatalf("step %d: wrong RemoveFirstOccurrence(%d): got %v want %v", step, v, errH, errPQ)
+			}
+		case op < 9:
+			other := New()
+			for i := r.Intn(5); i > 0; i-- {
+				v := r.Intn(100)
+				other.Add(v)
+				pq.Add(v)
+			}
+			h.Union(&other)
+		default:
+			v := r.Intn(100)
+			if got, want := h.Contains(v), pq.Contains(v); got != want {
+				t.Fatalf("step %d: wrong Contains(%d): got %t want %t", step, v, got, want)
+			}
+		}
+
+		if h.Size() != pq.Size() {
+			t.Fatalf("step %d: wrong size: got %d want %d", step, h.Size(), pq.Size())
+		}
+		if !pq.IsEmpty() && h.Peek() != pq.Peek() {
+			t.Fatalf("step %d: wrong Peek: got %d want %d", step, h.Peek(), pq.Peek())
+		}
+	}
+}
@@ -0,0 +1,345 @@
+package fibonacciheap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Node is an element of the FibonacciHeap. It is returned by Add and can be
+// used as an handle for DecreaseKey and Delete.
+type Node struct {
+	value  int
+	parent *Node
+	child  *Node
+	left   *Node
+	right  *Node
+	degree int
+	// marked tells whether the node has lost a child since it became the
+	// child of its current parent.
+	marked  bool
+	removed bool
+	// owner identifies the heap that the node belongs to.
+	owner *owner
+}
+
+// Value returns the value of the node `n`.
+func (n *Node) Value() int {
+	return n.value
+}
+
+// FibonacciHeap is a collection of heap-ordered trees, kept in a circular
+// doubly linked root list, that defers all the restructuring work to
+// RemoveMin. It removes the element with the least value first.
+type FibonacciHeap struct {
+	min   *Node
+	size  int
+	owner *owner
+}
+
+// owner identifies a heap. Melding a heap forwards its owner to the one of
+// the receiving heap, which keeps Meld constant time while still telling
+// apart the handles of different heaps.
+type owner struct {
+	next *owner
+}
+
+// resolve returns the owner that `o` has been forwarded to, shortening the
+// chain along the way.
+func (o *owner) resolve() *owner {
+	for o.next != nil {
+		if o.next.next != nil {
+			o.next = o.next.next
+		}
+		o = o.next
+	}
+	return o
+}
+
+// New returns a new FibonacciHeap instance.
+func New() FibonacciHeap {
+	return FibonacciHeap{min: nil, size: 0, owner: &owner{}}
+}
+
+// Size returns the number of elements that are into the heap.
+//
+// Complexity: O(1)
+func (h *FibonacciHeap) Size() int {
+	return h.size
+}
+
+// IsEmpty returns whether the heap is empty or not.
+//
+// Complexity: O(1)
+func (h *FibonacciHeap) IsEmpty() bool {
+	return h.Size() == 0
+}
+
+// Add adds a new element with the specified `value` to the heap and returns
+// its handle.
+//
+// Complexity: O(1)
+func (h *FibonacciHeap) Add(value int) *Node {
+	n := &Node{value: value, owner: h.id()}
+	n.left, n.right = n, n
+	h.addRoot(n)
+	h.size++
+	return n
+}
+
+// Peek returns the value of the next element that would be returned by
+// RemoveMin.
+//
+// Complexity: O(1)
+func (h *FibonacciHeap) Peek() int {
+	if h.IsEmpty() {
+		panic("Peek: cannot peek from an empty heap")
+	}
+	return h.min.value
+}
+
+// RemoveMin removes the element with the least value from the heap and
+// returns its value.
+//
+// Complexity: O(log(n)) amortized
+func (h *FibonacciHeap) RemoveMin() int {
+	if h.IsEmpty() {
+		panic("RemoveMin: cannot remove from an empty heap")
+	}
+	res := h.min
+
+	// Move every child of the minimum into the root list.
+	for res.child != nil {
+		c := res.child
+		if c.right == c {
+			res.child = nil
+		} else {
+			res.child = c.right
+		}
+		unlink(c)
+		c.parent = nil
+		c.marked = false
+		splice(res, c)
+	}
+
+	// Remove the minimum from the root list.
+	if res.right == res {
+		h.min = nil
+	} else {
+		h.min = res.right
+		unlink(res)
+		h.consolidate()
+	}
+	h.size--
+	res.removed = true
+	return res.value
+}
+
+// Meld moves all the elements of `other` into the heap, leaving `other`
+// empty. Handles of `other` remain valid for the heap.
+//
+// Complexity: O(1)
+func (h *FibonacciHeap) Meld(other *FibonacciHeap) {
+	if other == h {
+		panic("Meld: cannot meld a heap with itself")
+	}
+	if other.owner != nil {
+		other.owner.next = h.id()
+		other.owner = &owner{}
+	}
+	if other.min != nil {
+		h.addRoot(other.min)
+		h.size += other.size
+	}
+	other.min = nil
+	other.size = 0
+}
+
+// DecreaseKey changes the value of the element `node` to `value`, that must
+// not be greater than the current one.
+//
+// Complexity: O(1) amortized
+func (h *FibonacciHeap) DecreaseKey(node *Node, value int) error {
+	if !h.owns(node) {
+		return fmt.Errorf("cannot decrease key of a node not in the heap")
+	}
+	if value > node.value {
+		return fmt.Errorf("cannot decrease key from %d to %d", node.value, value)
+	}
+	node.value = value
+	if p := node.parent; p != nil && node.value < p.value {
+		h.cut(node)
+		h.cascadingCut(p)
+	}
+	if node.value < h.min.value {
+		h.min = node
+	}
+	return nil
+}
+
+// Delete removes the element `node` from the heap.
+//
+// Complexity: O(log(n)) amortized
+func (h *FibonacciHeap) Delete(node *Node) error {
+	if !h.owns(node) {
+		return fmt.Errorf("cannot delete a node not in the heap")
+	}
+
+	// Move the node to the root list, as if its value were decreased to
+	// minus infinity, then remove it as the minimum.
+	if p := node.parent; p != nil {
+		h.cut(node)
+		h.cascadingCut(p)
+	}
+	h.min = node
+	h.RemoveMin()
+	return nil
+}
+
+// owns returns whether `node` is an element of the heap.
+func (h *FibonacciHeap) owns(node *Node) bool {
+	return node != nil && !node.removed && node.owner.resolve() == h.id()
+}
+
+// id returns the owner of the heap, creating it for the zero value.
+func (h *FibonacciHeap) id() *owner {
+	if h.owner == nil {
+		h.owner = &owner{}
+	}
+	return h.owner
+}
+
+// addRoot splices the circular list starting at `n` into the root list and
+// updates the minimum.
+func (h *FibonacciHeap) addRoot(n *Node) {
+	if h.min == nil {
+		h.min = n
+		return
+	}
+	splice(h.min, n)
+	if n.value < h.min.value {
+		h.min = n
+	}
+}
+
+// consolidate links together the roots with the same degree until every root
+// has a different degree, then finds the new minimum.
+func (h *FibonacciHeap) consolidate() {
+	var roots []*Node
+	for n := h.min; ; {
+		roots = append(roots, n)
+		n = n.right
+		if n == h.min {
+			break
+		}
+	}
+
+	var byDegree []*Node
+	for _, n := range roots {
+		unlink(n)
+		for {
+			for len(byDegree) <= n.degree {
+				byDegree = append(byDegree, nil)
+			}
+			other := byDegree[n.degree]
+			if other == nil {
+				break
+			}
+			byDegree[n.degree] = nil
+			if other.value < n.value {
+				n, other = other, n
+			}
+			link(other, n)
+		}
+		byDegree[n.degree] = n
+	}
+
+	h.min = nil
+	for _, n := range byDegree {
+		if n != nil {
+			h.addRoot(n)
+		}
+	}
+}
+
+// cut moves `node` from the children of its parent to the root list.
+func (h *FibonacciHeap) cut(node *Node) {
+	p := node.parent
+	if node.right == node {
+		p.child = nil
+	} else {
+		if p.child == node {
+			p.child = node.right
+		}
+		unlink(node)
+	}
+	p.degree--
+	node.parent = nil
+	node.marked = false
+	splice(h.min, node)
+}
+
+// cascadingCut cuts `node` from its parent if it has already lost a child,
+// otherwise it marks it, and repeats the process up the tree.
+func (h *FibonacciHeap) cascadingCut(node *Node) {
+	for p := node.parent; p != nil; node, p = p, p.parent {
+		if !node.marked {
+			node.marked = true
+			return
+		}
+		h.cut(node)
+	}
+}
+
+// link makes the root `child` a child of the root `parent`.
+func link(child, parent *Node) {
+	child.parent = parent
+	child.marked = false
+	if parent.child == nil {
+		parent.child = child
+	} else {
+		splice(parent.child, child)
+	}
+	parent.degree++
+}
+
+// unlink removes `n` from its circular list, leaving it alone in a list of
+// its own.
+func unlink(n *Node) {
+	n.left.right = n.right
+	n.right.left = n.left
+	n.left, n.right = n, n
+}
+
+// splice joins the circular lists that contain `a` and `b`.
+func splice(a, b *Node) {
+	aRight, bLeft := a.right, b.left
+	a.right = b
+	b.left = a
+	bLeft.right = aRight
+	aRight.left = bLeft
+}
+
+func (h FibonacciHeap) String() string {
+	var b strings.Builder
+	b.WriteString("[ ")
+	var walk func(first *Node)
+	walk = func(first *Node) {
+		n := first
+		for {
+			fmt.Fprintf(&b, "%d ", n.value)
+			if n.child != nil {
+				b.WriteString("( ")
+				walk(n.child)
+				b.WriteString(") ")
+			}
+			n = n.right
+			if n == first {
+				break
+			}
+		}
+	}
+	if h.min != nil {
+		walk(h.min)
+	}
+	return b.String() + "]"
+}
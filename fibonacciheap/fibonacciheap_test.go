@@ -0,0 +1,269 @@
+package fibonacciheap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/BuriedInTheGround/datastructures/priorityqueue"
+)
+
+func TestRemoveMin(t *testing.T) {
+	h := New()
+
+	h.Add(2)
+	h.Add(5)
+	h.Add(4)
+	h.Add(1)
+	h.Add(3)
+
+	if h.Size() != 5 {
+		t.Errorf("the heap should have a size of %d, but it does not", 5)
+	}
+	if v := h.Peek(); v != 1 {
+		t.Errorf("wrong data: got %d want %d", v, 1)
+	}
+	for i := 1; !h.IsEmpty(); i++ {
+		if v := h.RemoveMin(); v != i {
+			t.Errorf("wrong data: got %d want %d", v, i)
+		}
+	}
+}
+
+func TestConsolidate(t *testing.T) {
+	h := New()
+	for i := 0; i < 16; i++ {
+		h.Add(i)
+	}
+	h.RemoveMin()
+
+	// The 15 remaining roots are linked into trees of distinct degrees, like
+	// the bits of 15: 1 + 2 + 4 + 8 nodes.
+	var degrees []int
+	for n := h.min; ; {
+		degrees = append(degrees, n.degree)
+		n = n.right
+		if n == h.min {
+			break
+		}
+	}
+	sort.Ints(degrees)
+	want := []int{0, 1, 2, 3}
+	if len(degrees) != len(want) {
+		t.Fatalf("wrong number of roots: got %v want %v", degrees, want)
+	}
+	for i := range want {
+		if degrees[i] != want[i] {
+			t.Errorf("wrong root degrees: got %v want %v", degrees, want)
+			break
+		}
+	}
+}
+
+func TestCascadingCut(t *testing.T) {
+	h := New()
+	for i := 0; i < 9; i++ {
+		h.Add(i)
+	}
+	h.RemoveMin()
+
+	// The 8 remaining elements form a single tree of degree 3: take a child
+	// of the root that has children of its own.
+	root := h.min
+	p := root.child
+	for p.degree < 2 {
+		p = p.right
+	}
+	c1, c2 := p.child, p.child.right
+
+	// Losing the first child only marks the parent.
+	if err := h.DecreaseKey(c1, -1); err != nil {
+		t.Errorf("error while decreasing key: %v", err)
+	}
+	if c1.parent != nil || !p.marked || p.parent != root {
+		t.Errorf("the first cut should have marked the parent and nothing more")
+	}
+
+	// Losing the second one cuts the parent as well.
+	if err := h.DecreaseKey(c2, -2); err != nil {
+		t.Errorf("error while decreasing key: %v", err)
+	}
+	if p.parent != nil || p.marked {
+		t.Errorf("the second cut should have moved the parent to the root list")
+	}
+	if root.marked {
+		t.Errorf("a root must never be marked")
+	}
+
+	if v := h.Peek(); v != -2 {
+		t.Errorf("wrong data: got %d want %d", v, -2)
+	}
+	for prev := h.RemoveMin(); !h.IsEmpty(); {
+		v := h.RemoveMin()
+		if v < prev {
+			t.Errorf("wrong order: got %d after %d", v, prev)
+		}
+		prev = v
+	}
+}
+
+func TestDecreaseKeyAndDelete(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	h := New()
+	nodes := make([]*Node, 0)
+	for _, v := range r.Perm(500) {
+		nodes = append(nodes, h.Add(v+1000))
+	}
+	h.RemoveMin() // Force some structure before changing keys.
+
+	var want []int
+	for i, n := range nodes {
+		if n.removed {
+			continue
+		}
+		switch i % 3 {
+		case 0:
+			if err := h.DecreaseKey(n, n.Value()-r.Intn(2000)); err != nil {
+				t.Errorf("error while decreasing key: %v", err)
+			}
+			want = append(want, n.Value())
+		case 1:
+			if err := h.Delete(n); err != nil {
+				t.Errorf("error while deleting: %v", err)
+			}
+		default:
+			want = append(want, n.Value())
+		}
+	}
+
+	if err := h.DecreaseKey(nodes[1], 0); err == nil {
+		t.Errorf("decrease key of a deleted node should have returned an error")
+	}
+	if err := h.DecreaseKey(nodes[2], nodes[2].Value()+1); err == nil {
+		t.Errorf("decrease key to a bigger value should have returned an error")
+	}
+
+	sort.Ints(want)
+	if h.Size() != len(want) {
+		t.Errorf("wrong size: got %d want %d", h.Size(), len(want))
+	}
+	for _, w := range want {
+		if v := h.RemoveMin(); v != w {
+			t.Errorf("wrong data: got %d want %d", v, w)
+		}
+	}
+}
+
+func TestOwnership(t *testing.T) {
+	h1 := New()
+	h2 := New()
+	h1.Add(1)
+	n1 := h1.Add(2)
+	n2 := h2.Add(3)
+
+	if err := h1.DecreaseKey(n2, 0); err == nil {
+		t.Errorf("decrease key of a node of another heap should have returned an error")
+	}
+	if err := h2.Delete(n1); err == nil {
+		t.Errorf("delete of a node of another heap should have returned an error")
+	}
+
+	// After melding, the handles follow their elements.
+	h1.Meld(&h2)
+	n3 := h2.Add(4)
+	if err := h1.DecreaseKey(n2, 0); err != nil {
+		t.Errorf("error while decreasing key of a melded node: %v", err)
+	}
+	if err := h1.Delete(n3); err == nil {
+		t.Errorf("delete of a node added after the meld should have returned an error")
+	}
+	if s1, s2 := h1.Size(), h2.Size(); s1 != 3 || s2 != 1 {
+		t.Errorf("wrong sizes: got %d and %d want %d and %d", s1, s2, 3, 1)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("meld of a heap with itself did not panic")
+		}
+	}()
+	h1.Meld(&h1)
+}
+
+func BenchmarkAddRemoveMin(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	h := New()
+	for i := 0; i < b.N; i++ {
+		h.Add(r.Int())
+		h.Add(r.Int())
+		h.RemoveMin()
+	}
+}
+
+func BenchmarkMinPQAddRemoveMin(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	pq := priorityqueue.New()
+	for i := 0; i < b.N; i++ {
+		pq.Add(r.Int())
+		pq.Add(r.Int())
+		pq.RemoveMin()
+	}
+}
+
+func BenchmarkMeld(b *testing.B) {
+	h := New()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		other := New()
+		for j := 0; j < 100; j++ {
+			other.Add(j)
+		}
+		b.StartTimer()
+		h.Meld(&other)
+	}
+}
+
+func BenchmarkMinPQMeld(b *testing.B) {
+	pq := priorityqueue.New()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		other := priorityqueue.New()
+		for j := 0; j < 100; j++ {
+			other.Add(j)
+		}
+		b.StartTimer()
+		pq.Meld(&other)
+	}
+}
+
+// BenchmarkDecreaseKey and BenchmarkIndexedPQDecreaseKey do the same
+// operations with the same random numbers: two additions, the decrease of a
+// random element, if still there, and a removal.
+func BenchmarkDecreaseKey(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	h := New()
+	var nodes []*Node
+	for i := 0; i < b.N; i++ {
+		nodes = append(nodes, h.Add(r.Intn(1<<30)), h.Add(r.Intn(1<<30)))
+		n, delta := nodes[r.Intn(len(nodes))], r.Intn(1<<10)
+		if !n.removed {
+			h.DecreaseKey(n, n.Value()-delta)
+		}
+		h.RemoveMin()
+	}
+}
+
+func BenchmarkIndexedPQDecreaseKey(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	pq := priorityqueue.NewIndexedMin[int, int]()
+	ids := 0
+	for i := 0; i < b.N; i++ {
+		pq.Insert(ids, r.Intn(1<<30))
+		pq.Insert(ids+1, r.Intn(1<<30))
+		ids += 2
+		id, delta := r.Intn(ids), r.Intn(1<<10)
+		if p, ok := pq.Priority(id); ok {
+			pq.DecreaseKey(id, p-delta)
+		}
+		pq.Remove()
+	}
+}
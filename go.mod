@@ -1,3 +1,3 @@
 module github.com/BuriedInTheGround/datastructures
 
-go 1.14
+go 1.21
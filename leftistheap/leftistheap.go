@@ -0,0 +1,137 @@
+package leftistheap
+
+import "fmt"
+
+// node is a vertex of the leftist tree. Nodes are never modified once
+// created, so they can be shared among different versions of the heap.
+type node struct {
+	value int
+	// rank is the length of the right spine, that in a leftist tree is the
+	// shortest path to a missing child.
+	rank  int
+	size  int
+	left  *node
+	right *node
+}
+
+// LeftistHeap is a persistent (immutable) heap: every operation that would
+// modify it returns a new version instead, sharing the unchanged subtrees
+// with the old one, that stays fully usable. It removes the element with the
+// least value first.
+//
+// The zero value is an empty heap.
+type LeftistHeap struct {
+	root *node
+}
+
+// New returns a new, empty, LeftistHeap instance.
+func New() LeftistHeap {
+	return LeftistHeap{root: nil}
+}
+
+// Size returns the number of elements that are into the heap.
+//
+// Complexity: O(1)
+func (h LeftistHeap) Size() int {
+	return size(h.root)
+}
+
+// IsEmpty returns whether the heap is empty or not.
+//
+// Complexity: O(1)
+func (h LeftistHeap) IsEmpty() bool {
+	return h.root == nil
+}
+
+// Peek returns the value of the next element that would be returned by
+// RemoveMin.
+//
+// Complexity: O(1)
+func (h LeftistHeap) Peek() int {
+	if h.IsEmpty() {
+		panic("Peek: cannot peek from an empty heap")
+	}
+	return h.root.value
+}
+
+// Add returns a new version of the heap with an additional element with the
+// specified `value`.
+//
+// Complexity: O(log(n))
+func (h LeftistHeap) Add(value int) LeftistHeap {
+	return LeftistHeap{root: merge(h.root, &node{value: value, rank: 1, size: 1})}
+}
+
+// RemoveMin returns the value of the element with the least value and a new
+// version of the heap without it.
+//
+// Complexity: O(log(n))
+func (h LeftistHeap) RemoveMin() (int, LeftistHeap) {
+	if h.IsEmpty() {
+		panic("RemoveMin: cannot remove from an empty heap")
+	}
+	return h.root.value, LeftistHeap{root: merge(h.root.left, h.root.right)}
+}
+
+// Merge returns a new heap with the elements of both the heap and `other`.
+//
+// Complexity: O(log(n))
+func (h LeftistHeap) Merge(other LeftistHeap) LeftistHeap {
+	return LeftistHeap{root: merge(h.root, other.root)}
+}
+
+// merge merges two leftist trees walking down their right spines, copying
+// only the nodes on those paths.
+func merge(a, b *node) *node {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if b.value < a.value {
+		a, b = b, a
+	}
+
+	// Keep the leftist property: the left child has the greater rank.
+	left, right := a.left, merge(a.right, b)
+	if rank(left) < rank(right) {
+		left, right = right, left
+	}
+	return &node{
+		value: a.value,
+		rank:  rank(right) + 1,
+		size:  size(left) + size(right) + 1,
+		left:  left,
+		right: right,
+	}
+}
+
+func rank(n *node) int {
+	if n == nil {
+		return 0
+	}
+	return n.rank
+}
+
+func size(n *node) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (h LeftistHeap) String() string {
+	res := "[ "
+	var walk func(n *node)
+	walk = func(n *node) {
+		if n == nil {
+			return
+		}
+		res += fmt.Sprintf("%d ", n.value)
+		walk(n.left)
+		walk(n.right)
+	}
+	walk(h.root)
+	return res + "]"
+}
@@ -0,0 +1,74 @@
+package leftistheap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func drain(h LeftistHeap) []int {
+	var res []int
+	for !h.IsEmpty() {
+		var v int
+		v, h = h.RemoveMin()
+		res = append(res, v)
+	}
+	return res
+}
+
+func TestRemoveMin(t *testing.T) {
+	h := New()
+
+	for _, v := range []int{2, 5, 4, 1, 3} {
+		h = h.Add(v)
+	}
+
+	if h.Size() != 5 {
+		t.Errorf("the heap should have a size of %d, but it does not", 5)
+	}
+	if v := h.Peek(); v != 1 {
+		t.Errorf("wrong data: got %d want %d", v, 1)
+	}
+	for i, v := range drain(h) {
+		if v != i+1 {
+			t.Errorf("wrong data: got %d want %d", v, i+1)
+		}
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	r := rand.New(rand.NewSource(23))
+	base := New()
+	var baseValues []int
+	for i := 0; i < 100; i++ {
+		v := r.Intn(1000)
+		base = base.Add(v)
+		baseValues = append(baseValues, v)
+	}
+
+	// Branch the base version in many ways, then check that every version,
+	// including the base one, still holds exactly its own elements.
+	_, removed := base.RemoveMin()
+	added := base.Add(-1)
+	merged := base.Merge(added)
+
+	sort.Ints(baseValues)
+	check := func(name string, h LeftistHeap, want []int) {
+		got := drain(h)
+		if len(got) != len(want) {
+			t.Fatalf("%s: wrong size: got %d want %d", name, len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s: wrong data at %d: got %d want %d", name, i, got[i], want[i])
+			}
+		}
+	}
+
+	check("removed", removed, baseValues[1:])
+	check("added", added, append([]int{-1}, baseValues...))
+	both := append(append([]int{-1}, baseValues...), baseValues...)
+	sort.Ints(both)
+	check("merged", merged, both)
+	check("base", base, baseValues)
+}
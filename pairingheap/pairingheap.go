@@ -0,0 +1,266 @@
+package pairingheap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Node is an element of the PairingHeap. It is returned by Add and can be
+// used as an handle for DecreaseKey and Delete.
+type Node struct {
+	value   int
+	child   *Node
+	sibling *Node
+	// prev points to the left sibling, or to the parent if the node is the
+	// leftmost child.
+	prev    *Node
+	removed bool
+	// owner identifies the heap that the node belongs to.
+	owner *owner
+}
+
+// Value returns the value of the node `n`.
+func (n *Node) Value() int {
+	return n.value
+}
+
+// owner identifies a heap. When a heap is melded into another one, its owner
+// is forwarded to the owner of the other heap, so that the handles of both
+// heaps are recognized without updating every node.
+type owner struct {
+	next *owner
+}
+
+// resolve returns the owner that `o` has been forwarded to, shortening the
+// chain along the way.
+func (o *owner) resolve() *owner {
+	for o.next != nil {
+		if o.next.next != nil {
+			o.next = o.next.next
+		}
+		o = o.next
+	}
+	return o
+}
+
+// PairingHeap is a heap-ordered multi-way tree that supports merging two
+// heaps in constant time. It removes the element with the least value first.
+type PairingHeap struct {
+	root  *Node
+	size  int
+	owner *owner
+}
+
+// New returns a new PairingHeap instance.
+func New() PairingHeap {
+	return PairingHeap{root: nil, size: 0, owner: &owner{}}
+}
+
+// Size returns the number of elements that are into the heap.
+//
+// Complexity: O(1)
+func (h *PairingHeap) Size() int {
+	return h.size
+}
+
+// IsEmpty returns whether the heap is empty or not.
+//
+// Complexity: O(1)
+func (h *PairingHeap) IsEmpty() bool {
+	return h.Size() == 0
+}
+
+// Add adds a new element with the specified `value` to the heap and returns
+// its handle.
+//
+// Complexity: O(1)
+func (h *PairingHeap) Add(value int) *Node {
+	n := &Node{value: value, owner: h.id()}
+	h.root = link(h.root, n)
+	h.size++
+	return n
+}
+
+// Peek returns the value of the next element that would be returned by
+// RemoveMin.
+//
+// Complexity: O(1)
+func (h *PairingHeap) Peek() int {
+	if h.IsEmpty() {
+		panic("Peek: cannot peek from an empty heap")
+	}
+	return h.root.value
+}
+
+// RemoveMin removes the element with the least value from the heap and
+// returns its value.
+//
+// Complexity: O(log(n)) amortized
+func (h *PairingHeap) RemoveMin() int {
+	if h.IsEmpty() {
+		panic("RemoveMin: cannot remove from an empty heap")
+	}
+	res := h.root
+	h.root = mergePairs(res.child)
+	h.size--
+	res.child = nil
+	res.removed = true
+	return res.value
+}
+
+// Meld moves all the elements of `other` into the heap, leaving `other`
+// empty. Handles of `other` remain valid for the heap.
+//
+// Complexity: O(1)
+func (h *PairingHeap) Meld(other *PairingHeap) {
+	if other == h {
+		panic("Meld: cannot meld a heap with itself")
+	}
+	if other.owner != nil {
+		other.owner.next = h.id()
+		other.owner = &owner{}
+	}
+	h.root = link(h.root, other.root)
+	h.size += other.size
+	other.root = nil
+	other.size = 0
+}
+
+// DecreaseKey changes the value of the element `node` to `value`, that must
+// not be greater than the current one.
+//
+// Complexity: O(log(n)) amortized
+func (h *PairingHeap) DecreaseKey(node *Node, value int) error {
+	if !h.owns(node) {
+		return fmt.Errorf("cannot decrease key of a node not in the heap")
+	}
+	if value > node.value {
+		return fmt.Errorf("cannot decrease key from %d to %d", node.value, value)
+	}
+	node.value = value
+	if node != h.root {
+		cut(node)
+		h.root = link(h.root, node)
+	}
+	return nil
+}
+
+// Delete removes the element `node` from the heap.
+//
+// Complexity: O(log(n)) amortized
+func (h *PairingHeap) Delete(node *Node) error {
+	if !h.owns(node) {
+		return fmt.Errorf("cannot delete a node not in the heap")
+	}
+	if node == h.root {
+		h.RemoveMin()
+		return nil
+	}
+	cut(node)
+	h.root = link(h.root, mergePairs(node.child))
+	h.size--
+	node.child = nil
+	node.removed = true
+	return nil
+}
+
+// owns returns whether `node` is an element of the heap.
+func (h *PairingHeap) owns(node *Node) bool {
+	return node != nil && !node.removed && node.owner.resolve() == h.id()
+}
+
+// id returns the owner of the heap, creating it for the zero value.
+func (h *PairingHeap) id() *owner {
+	if h.owner == nil {
+		h.owner = &owner{}
+	}
+	return h.owner
+}
+
+// link makes the root with the greater value the leftmost child of the other
+// one and returns the new root.
+func link(a, b *Node) *Node {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if b.value < a.value {
+		a, b = b, a
+	}
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	a.sibling = nil
+	a.prev = nil
+	return a
+}
+
+// cut detaches the subtree rooted at `node` from its parent.
+func cut(node *Node) {
+	if node.prev.child == node {
+		node.prev.child = node.sibling
+	} else {
+		node.prev.sibling = node.sibling
+	}
+	if node.sibling != nil {
+		node.sibling.prev = node.prev
+	}
+	node.sibling = nil
+	node.prev = nil
+}
+
+// mergePairs melds a list of siblings into a single tree with the two-pass
+// strategy: first link them in pairs from left to right, then link the
+// resulting trees from right to left.
+func mergePairs(first *Node) *Node {
+	if first == nil {
+		return nil
+	}
+
+	// First pass, left to right.
+	var pairs []*Node
+	for first != nil {
+		a := first
+		b := a.sibling
+		if b == nil {
+			first = nil
+		} else {
+			first = b.sibling
+		}
+		a.sibling, a.prev = nil, nil
+		if b != nil {
+			b.sibling, b.prev = nil, nil
+		}
+		pairs = append(pairs, link(a, b))
+	}
+
+	// Second pass, right to left.
+	root := pairs[len(pairs)-1]
+	for i := len(pairs) - 2; i >= 0; i-- {
+		root = link(pairs[i], root)
+	}
+	return root
+}
+
+func (h PairingHeap) String() string {
+	var b strings.Builder
+	b.WriteString("[ ")
+	var walk func(n *Node)
+	walk = func(n *Node) {
+		for ; n != nil; n = n.sibling {
+			fmt.Fprintf(&b, "%d ", n.value)
+			if n.child != nil {
+				b.WriteString("( ")
+				walk(n.child)
+				b.WriteString(") ")
+			}
+		}
+	}
+	walk(h.root)
+	return b.String() + "]"
+}
@@ -0,0 +1,240 @@
+package pairingheap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/BuriedInTheGround/datastructures/priorityqueue"
+)
+
+func TestRemoveMin(t *testing.T) {
+	h := New()
+
+	h.Add(2)
+	h.Add(5)
+	h.Add(4)
+	h.Add(1)
+	h.Add(3)
+
+	if h.Size() != 5 {
+		t.Errorf("the heap should have a size of %d, but it does not", 5)
+	}
+	if v := h.Peek(); v != 1 {
+		t.Errorf("wrong data: got %d want %d", v, 1)
+	}
+	for i := 1; !h.IsEmpty(); i++ {
+		if v := h.RemoveMin(); v != i {
+			t.Errorf("wrong data: got %d want %d", v, i)
+		}
+	}
+}
+
+func TestMeld(t *testing.T) {
+	h1 := New()
+	h2 := New()
+
+	for i := 1; i <= 10; i++ {
+		if i%2 == 0 {
+			h1.Add(i)
+		} else {
+			h2.Add(i)
+		}
+	}
+	h1.Meld(&h2)
+
+	if !h2.IsEmpty() {
+		t.Errorf("the melded heap should be empty, but it is not")
+	}
+	for i := 1; !h1.IsEmpty(); i++ {
+		if v := h1.RemoveMin(); v != i {
+			t.Errorf("wrong data: got %d want %d", v, i)
+		}
+	}
+}
+
+func TestTwoPassPairing(t *testing.T) {
+	h := New()
+	for i := 1; i <= 9; i++ {
+		h.Add(i)
+	}
+
+	// Every new element becomes the leftmost child of the root.
+	if s := h.String(); s != "[ 1 ( 9 8 7 6 5 4 3 2 ) ]" {
+		t.Errorf("wrong shape: got %q", s)
+	}
+
+	// The children are first paired from left to right, giving 8, 6, 4 and
+	// 2, then linked from right to left into 2.
+	h.RemoveMin()
+	if s := h.String(); s != "[ 2 ( 8 ( 9 ) 6 ( 7 ) 4 ( 5 ) 3 ) ]" {
+		t.Errorf("wrong shape: got %q", s)
+	}
+}
+
+func TestDecreaseKeyAndDelete(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	h := New()
+	nodes := make([]*Node, 0)
+	for _, v := range r.Perm(500) {
+		nodes = append(nodes, h.Add(v+1000))
+	}
+	h.RemoveMin() // Force some structure before changing keys.
+
+	var want []int
+	for i, n := range nodes {
+		if n.removed {
+			continue
+		}
+		switch i % 3 {
+		case 0:
+			if err := h.DecreaseKey(n, n.Value()-r.Intn(2000)); err != nil {
+				t.Errorf("error while decreasing key: %v", err)
+			}
+			want = append(want, n.Value())
+		case 1:
+			if err := h.Delete(n); err != nil {
+				t.Errorf("error while deleting: %v", err)
+			}
+		default:
+			want = append(want, n.Value())
+		}
+	}
+
+	if err := h.DecreaseKey(nodes[1], 0); err == nil {
+		t.Errorf("decrease key of a deleted node should have returned an error")
+	}
+	if err := h.DecreaseKey(nodes[2], nodes[2].Value()+1); err == nil {
+		t.Errorf("decrease key to a bigger value should have returned an error")
+	}
+
+	sort.Ints(want)
+	if h.Size() != len(want) {
+		t.Errorf("wrong size: got %d want %d", h.Size(), len(want))
+	}
+	for _, w := range want {
+		if v := h.RemoveMin(); v != w {
+			t.Errorf("wrong data: got %d want %d", v, w)
+		}
+	}
+}
+
+func TestOwnership(t *testing.T) {
+	h1 := New()
+	h2 := New()
+	h1.Add(1)
+	h2.Add(0)
+	n1 := h1.Add(2)
+	root2 := h2.Peek()
+	n2 := h2.Add(3)
+
+	// Neither the root nor any other node of a heap belongs to another one.
+	if err := h1.Delete(n2); err == nil {
+		t.Errorf("delete of a node of another heap should have returned an error")
+	}
+	if err := h2.DecreaseKey(n1, root2); err == nil {
+		t.Errorf("decrease key of a node of another heap should have returned an error")
+	}
+	if err := h1.Delete(h2.root); err == nil {
+		t.Errorf("delete of the root of another heap should have returned an error")
+	}
+
+	// After melding, the handles follow their elements.
+	h1.Meld(&h2)
+	n3 := h2.Add(4)
+	if err := h1.Delete(n2); err != nil {
+		t.Errorf("error while deleting a melded node: %v", err)
+	}
+	if err := h1.DecreaseKey(n3, 0); err == nil {
+		t.Errorf("decrease key of a node added after the meld should have returned an error")
+	}
+	if s1, s2 := h1.Size(), h2.Size(); s1 != 3 || s2 != 1 {
+		t.Errorf("wrong sizes: got %d and %d want %d and %d", s1, s2, 3, 1)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("meld of a heap with itself did not panic")
+		}
+	}()
+	h1.Meld(&h1)
+}
+
+func BenchmarkAddRemoveMin(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	h := New()
+	for i := 0; i < b.N; i++ {
+		h.Add(r.Int())
+		h.Add(r.Int())
+		h.RemoveMin()
+	}
+}
+
+func BenchmarkMinPQAddRemoveMin(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	pq := priorityqueue.New()
+	for i := 0; i < b.N; i++ {
+		pq.Add(r.Int())
+		pq.Add(r.Int())
+		pq.RemoveMin()
+	}
+}
+
+func BenchmarkMeld(b *testing.B) {
+	h := New()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		other := New()
+		for j := 0; j < 100; j++ {
+			other.Add(j)
+		}
+		b.StartTimer()
+		h.Meld(&other)
+	}
+}
+
+func BenchmarkMinPQMeld(b *testing.B) {
+	pq := priorityqueue.New()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		other := priorityqueue.New()
+		for j := 0; j < 100; j++ {
+			other.Add(j)
+		}
+		b.StartTimer()
+		pq.Meld(&other)
+	}
+}
+
+// BenchmarkDecreaseKey and BenchmarkIndexedPQDecreaseKey do the same
+// operations with the same random numbers: two additions, the decrease of a
+// random element, if still there, and a removal.
+func BenchmarkDecreaseKey(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	h := New()
+	var nodes []*Node
+	for i := 0; i < b.N; i++ {
+		nodes = append(nodes, h.Add(r.Intn(1<<30)), h.Add(r.Intn(1<<30)))
+		n, delta := nodes[r.Intn(len(nodes))], r.Intn(1<<10)
+		if !n.removed {
+			h.DecreaseKey(n, n.Value()-delta)
+		}
+		h.RemoveMin()
+	}
+}
+
+func BenchmarkIndexedPQDecreaseKey(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	pq := priorityqueue.NewIndexedMin[int, int]()
+	ids := 0
+	for i := 0; i < b.N; i++ {
+		pq.Insert(ids, r.Intn(1<<30))
+		pq.Insert(ids+1, r.Intn(1<<30))
+		ids += 2
+		id, delta := r.Intn(ids), r.Intn(1<<10)
+		if p, ok := pq.Priority(id); ok {
+			pq.DecreaseKey(id, p-delta)
+		}
+		pq.Remove()
+	}
+}
@@ -0,0 +1,201 @@
+package priorityqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned when pushing to or popping from a closed
+// BlockingPQ.
+var ErrClosed = errors.New("priority queue is closed")
+
+// BlockingPQ is a priority queue that can be safely shared among goroutines.
+// Popping from an empty BlockingPQ waits until an element is pushed and, if
+// it has a capacity, pushing to a full one waits until an element is popped.
+//
+// The zero value is not ready to use, as it has no ordering: use
+// NewBlocking.
+type BlockingPQ[T any] struct {
+	mu       sync.Mutex
+	heap     PriorityQueue[T]
+	capacity int
+	closed   bool
+
+	// notEmpty and notFull are the goroutines waiting to pop and to push.
+	notEmpty waiters
+	notFull  waiters
+}
+
+// NewBlocking returns a new BlockingPQ instance ordered by `less`, that holds
+// at most `capacity` elements: pushing to a full PQ waits until an element is
+// popped. A `capacity` of zero makes the PQ unbounded.
+func NewBlocking[T any](less func(a, b T) bool, capacity int, opts ...Option) *BlockingPQ[T] {
+	if capacity < 0 {
+		panic("capacity must not be a negative number")
+	}
+	return &BlockingPQ[T]{
+		heap:     NewPriorityQueue(less, opts...),
+		capacity: capacity,
+	}
+}
+
+// Size returns the number of elements that are into the PQ.
+//
+// Complexity: O(1)
+func (pq *BlockingPQ[T]) Size() int {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	return pq.heap.Size()
+}
+
+// Push adds a new element with the specified `value` to the PQ. If the PQ is
+// full, it waits until there is room for it, `ctx` is done, or the PQ is
+// closed.
+//
+// Complexity: O(log(n))
+func (pq *BlockingPQ[T]) Push(ctx context.Context, value T) error {
+	for {
+		pq.mu.Lock()
+		if pq.closed {
+			pq.mu.Unlock()
+			return ErrClosed
+		}
+		if pq.capacity == 0 || pq.heap.Size() < pq.capacity {
+			pq.heap.Add(value)
+			pq.notEmpty.signal()
+			pq.mu.Unlock()
+			return nil
+		}
+		wake := pq.notFull.add()
+		pq.mu.Unlock()
+
+		select {
+		case <-wake:
+		case <-ctx.Done():
+			pq.mu.Lock()
+			pq.notFull.cancel(wake)
+			pq.mu.Unlock()
+			return ctx.Err()
+		}
+	}
+}
+
+// TryPop removes an element from the PQ, following the priority order, and
+// returns its value, if the PQ is not empty. It never waits.
+//
+// Complexity: O(log(n))
+func (pq *BlockingPQ[T]) TryPop() (T, bool) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	if pq.heap.IsEmpty() {
+		var zero T
+		return zero, false
+	}
+	res := pq.heap.Remove()
+	pq.notFull.signal()
+	return res, true
+}
+
+// Pop removes an element from the PQ, following the priority order, and
+// returns its value. If the PQ is empty, it waits until an element is pushed,
+// `ctx` is done, or the PQ is closed.
+//
+// The elements left in a closed PQ can still be popped: ErrClosed is only
+// returned once it is empty.
+//
+// Complexity: O(log(n))
+func (pq *BlockingPQ[T]) Pop(ctx context.Context) (T, error) {
+	for {
+		pq.mu.Lock()
+		if !pq.heap.IsEmpty() {
+			res := pq.heap.Remove()
+			pq.notFull.signal()
+			pq.mu.Unlock()
+			return res, nil
+		}
+		if pq.closed {
+			pq.mu.Unlock()
+			var zero T
+			return zero, ErrClosed
+		}
+		wake := pq.notEmpty.add()
+		pq.mu.Unlock()
+
+		select {
+		case <-wake:
+		case <-ctx.Done():
+			pq.mu.Lock()
+			pq.notEmpty.cancel(wake)
+			pq.mu.Unlock()
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// Close closes the PQ, waking up every goroutine that is waiting on it. Any
+// following Push fails with ErrClosed. Closing an already closed PQ has no
+// effect.
+func (pq *BlockingPQ[T]) Close() {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	if pq.closed {
+		return
+	}
+	pq.closed = true
+	pq.notEmpty.broadcast()
+	pq.notFull.broadcast()
+}
+
+// waiters is a queue of goroutines waiting for the state of a PQ to change,
+// each one woken up through its own channel. It must be used while holding
+// the lock of the PQ.
+type waiters []chan struct{}
+
+// add enqueues a new waiting goroutine and returns the channel on which it
+// will be woken up.
+func (w *waiters) add() chan struct{} {
+	wake := make(chan struct{}, 1)
+	*w = append(*w, wake)
+	return wake
+}
+
+// remove dequeues the goroutine waiting on `wake`, and reports whether it
+// was still waiting or it had already been woken up.
+func (w *waiters) remove(wake chan struct{}) bool {
+	for i, other := range *w {
+		if other == wake {
+			*w = append((*w)[:i], (*w)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// cancel dequeues the goroutine waiting on `wake`, which has stopped
+// waiting. If it had already been woken up, another goroutine is woken up in
+// its place, so that the change of state is not lost.
+func (w *waiters) cancel(wake chan struct{}) {
+	if !w.remove(wake) {
+		w.signal()
+	}
+}
+
+// signal wakes up the goroutine that has been waiting the longest, if any.
+func (w *waiters) signal() {
+	if len(*w) == 0 {
+		return
+	}
+	wake := (*w)[0]
+	(*w)[0] = nil
+	*w = (*w)[1:]
+	wake <- struct{}{}
+}
+
+// broadcast wakes up every waiting goroutine.
+func (w *waiters) broadcast() {
+	for len(*w) > 0 {
+		w.signal()
+	}
+}
@@ -0,0 +1,191 @@
+package priorityqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingPQPop(t *testing.T) {
+	pq := NewBlocking(func(a, b int) bool { return a < b }, 0)
+	ctx := context.Background()
+
+	if _, ok := pq.TryPop(); ok {
+		t.Errorf("the PQ is empty, TryPop should have failed")
+	}
+
+	const producers, perProducer = 8, 500
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				if err := pq.Push(ctx, p*perProducer+i); err != nil {
+					t.Errorf("error while pushing: %v", err)
+				}
+			}
+		}(p)
+	}
+
+	seen := make([]bool, producers*perProducer)
+	var mu sync.Mutex
+	var consumers sync.WaitGroup
+	for c := 0; c < 4; c++ {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				v, err := pq.Pop(ctx)
+				if errors.Is(err, ErrClosed) {
+					return
+				}
+				mu.Lock()
+				seen[v] = true
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	pq.Close()
+	consumers.Wait()
+
+	for v, ok := range seen {
+		if !ok {
+			t.Errorf("value %d has never been popped", v)
+		}
+	}
+}
+
+func TestBlockingPQPopCancel(t *testing.T) {
+	pq := NewBlocking(func(a, b int) bool { return a < b }, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error)
+	go func() {
+		_, err := pq.Pop(ctx)
+		done <- err
+	}()
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("wrong error: got %v want %v", err, context.Canceled)
+	}
+}
+
+func TestBlockingPQCapacity(t *testing.T) {
+	pq := NewBlocking(func(a, b int) bool { return a < b }, 2)
+	ctx := context.Background()
+
+	pq.Push(ctx, 3)
+	pq.Push(ctx, 1)
+
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := pq.Push(short, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("push to a full PQ should have waited: got %v", err)
+	}
+
+	pushed := make(chan error)
+	go func() {
+		pushed <- pq.Push(ctx, 2)
+	}()
+	if v, _ := pq.Pop(ctx); v != 1 {
+		t.Errorf("wrong data: got %d want %d", v, 1)
+	}
+	if err := <-pushed; err != nil {
+		t.Errorf("error while pushing: %v", err)
+	}
+	if s := pq.Size(); s != 2 {
+		t.Errorf("wrong size: got %d want %d", s, 2)
+	}
+}
+
+func TestBlockingPQCapacityContention(t *testing.T) {
+	pq := NewBlocking(func(a, b int) bool { return a < b }, 4)
+	ctx := context.Background()
+
+	// Goroutines giving up while waiting must not swallow the wake-ups meant
+	// for the others, or some producer or consumer would wait forever.
+	const producers, perProducer = 8, 200
+	var impatient sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		impatient.Add(1)
+		go func() {
+			defer impatient.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				short, cancel := context.WithTimeout(ctx, time.Microsecond)
+				pq.Push(short, -1)
+				pq.Pop(short)
+				cancel()
+			}
+		}()
+	}
+
+	var producersDone sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		producersDone.Add(1)
+		go func(p int) {
+			defer producersDone.Done()
+			for i := 0; i < perProducer; i++ {
+				if err := pq.Push(ctx, p*perProducer+i); err != nil {
+					t.Errorf("error while pushing: %v", err)
+				}
+			}
+		}(p)
+	}
+
+	var consumers sync.WaitGroup
+	for c := 0; c < 2; c++ {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				if _, err := pq.Pop(ctx); err != nil {
+					return
+				}
+			}
+		}()
+	}
+
+	producersDone.Wait()
+	close(stop)
+	impatient.Wait()
+	pq.Close()
+	consumers.Wait()
+
+	if s := pq.Size(); s != 0 {
+		t.Errorf("wrong size: got %d want %d", s, 0)
+	}
+}
+
+func TestBlockingPQClose(t *testing.T) {
+	pq := NewBlocking(func(a, b int) bool { return a < b }, 0)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := pq.Pop(ctx); !errors.Is(err, ErrClosed) {
+				t.Errorf("wrong error: got %v want %v", err, ErrClosed)
+			}
+		}()
+	}
+	pq.Close()
+	wg.Wait()
+
+	if err := pq.Push(ctx, 1); !errors.Is(err, ErrClosed) {
+		t.Errorf("wrong error: got %v want %v", err, ErrClosed)
+	}
+}
@@ -0,0 +1,244 @@
+package priorityqueue
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock tells the time to a DelayQueue and lets it wait for a duration.
+// It can be replaced to control time, for instance in tests.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single event created by a Clock, like time.Timer.
+type Timer interface {
+	// C returns the channel on which the time is delivered once the timer
+	// expires.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing.
+	Stop() bool
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	t *time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.t.C
+}
+
+func (t systemTimer) Stop() bool {
+	return t.t.Stop()
+}
+
+// Handle identifies an element scheduled into a DelayQueue, and can be used
+// to cancel it.
+type Handle[T any] struct {
+	value T
+	at    time.Time
+	seq   uint64
+	// index is the position into the heap, or -1 once the element has left
+	// the queue.
+	index int
+}
+
+// Value returns the scheduled value.
+func (h *Handle[T]) Value() T {
+	return h.value
+}
+
+// At returns the time at which the value becomes due.
+func (h *Handle[T]) At() time.Time {
+	return h.at
+}
+
+// DelayQueue holds values until the time they have been scheduled at, and
+// releases them ordered by due time. Values due at the same time are released
+// in the order they have been scheduled. A DelayQueue can be safely shared
+// among goroutines.
+//
+// The zero value is not ready to use, as it has neither ordering nor clock:
+// use NewDelayQueue.
+type DelayQueue[T any] struct {
+	mu    sync.Mutex
+	heap  PriorityQueue[*Handle[T]]
+	clock Clock
+	seq   uint64
+
+	// waiters are the goroutines waiting into Next. Only one of them, the
+	// leader, waits for the head of the queue to be due, while the others
+	// wait to be woken up when the leader leaves or the head changes.
+	waiters waiters
+	leader  chan struct{}
+}
+
+// NewDelayQueue returns a new DelayQueue instance that uses `clock` to decide
+// when values are due. A nil `clock` means the system clock.
+func NewDelayQueue[T any](clock Clock, opts ...Option) *DelayQueue[T] {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	heap := NewPriorityQueue(func(a, b *Handle[T]) bool {
+		if !a.at.Equal(b.at) {
+			return a.at.Before(b.at)
+		}
+		return a.seq < b.seq
+	}, opts...)
+	heap.moved = func(h *Handle[T], i int) {
+		h.index = i
+	}
+	return &DelayQueue[T]{heap: heap, clock: clock}
+}
+
+// Size returns the number of values that are waiting into the queue, either
+// due or not.
+//
+// Complexity: O(1)
+func (dq *DelayQueue[T]) Size() int {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	return dq.heap.Size()
+}
+
+// Schedule adds the specified `value` to the queue, to be released at time
+// `at`, and returns its handle.
+//
+// Complexity: O(log(n))
+func (dq *DelayQueue[T]) Schedule(value T, at time.Time) *Handle[T] {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	h := &Handle[T]{value: value, at: at, seq: dq.seq}
+	dq.seq++
+	dq.heap.Add(h)
+	if h.index == 0 {
+		dq.changeHead()
+	}
+	return h
+}
+
+// Cancel removes the value identified by `h` from the queue, and returns
+// whether it was still waiting or not.
+//
+// Complexity: O(log(n))
+func (dq *DelayQueue[T]) Cancel(h *Handle[T]) bool {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	if h.index < 0 || h.index >= dq.heap.Size() || dq.heap.data[h.index] != h {
+		return false
+	}
+	wasHead := h.index == 0
+	dq.heap.removeAt(h.index)
+	h.index = -1
+	if wasHead {
+		dq.changeHead()
+	}
+	return true
+}
+
+// TryNext removes the first value of the queue and returns it, if it is
+// already due. It never waits.
+//
+// Complexity: O(log(n))
+func (dq *DelayQueue[T]) TryNext() (T, bool) {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	if dq.heap.IsEmpty() || dq.heap.Peek().at.After(dq.clock.Now()) {
+		var zero T
+		return zero, false
+	}
+	return dq.pop(), true
+}
+
+// Next removes the first value of the queue and returns it, waiting until
+// it is due. If the queue is empty, it also waits for a value to be
+// scheduled. It stops waiting when `ctx` is done.
+//
+// Complexity: O(log(n))
+func (dq *DelayQueue[T]) Next(ctx context.Context) (T, error) {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	for {
+		var timer Timer
+		if !dq.heap.IsEmpty() {
+			wait := dq.heap.Peek().at.Sub(dq.clock.Now())
+			if wait <= 0 {
+				res := dq.pop()
+				// Someone else has to wait for the new head.
+				if dq.leader == nil && !dq.heap.IsEmpty() {
+					dq.waiters.signal()
+				}
+				return res, nil
+			}
+			if dq.leader == nil {
+				timer = dq.clock.NewTimer(wait)
+			}
+		}
+		wake := dq.waiters.add()
+		if timer != nil {
+			dq.leader = wake
+		}
+		dq.mu.Unlock()
+
+		// A nil channel blocks forever, so followers and waiters on an empty
+		// queue can only be woken up by a signal or `ctx`.
+		var expired <-chan time.Time
+		if timer != nil {
+			expired = timer.C()
+		}
+		var err error
+		select {
+		case <-expired:
+		case <-wake:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+
+		dq.mu.Lock()
+		if timer != nil {
+			timer.Stop()
+		}
+		wasLeader := dq.leader == wake
+		if wasLeader {
+			dq.leader = nil
+		}
+		// A signal that arrived along with the timer is not lost, since we
+		// are going to look at the head anyway, but one that arrived along
+		// with `ctx` has to be passed on, as well as the lead.
+		signaled := !dq.waiters.remove(wake)
+		if err != nil {
+			if signaled || wasLeader {
+				dq.waiters.signal()
+			}
+			var zero T
+			return zero, err
+		}
+	}
+}
+
+// changeHead wakes up a goroutine to wait for the new head of the queue in
+// place of the leader. It must be called while holding the lock.
+func (dq *DelayQueue[T]) changeHead() {
+	dq.leader = nil
+	dq.waiters.signal()
+}
+
+// pop removes the first value of the queue. It must be called while holding
+// the lock.
+func (dq *DelayQueue[T]) pop() T {
+	h := dq.heap.Remove()
+	h.index = -1
+	return h.value
+}
@@ -0,0 +1,227 @@
+package priorityqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock whose time only moves forward when Advance is called.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+
+	// events, if not nil, receives "now", "timer" and "stop" every time the
+	// time is read, a timer is created and a timer is stopped.
+	events chan string
+}
+
+type fakeTimer struct {
+	clock *fakeClock
+	at    time.Time
+	c     chan time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	defer c.report("now")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) Timer {
+	defer c.report("timer")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, at: c.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.c <- c.now
+	} else {
+		c.timers = append(c.timers, t)
+	}
+	return t
+}
+
+func (c *fakeClock) report(event string) {
+	if c.events != nil {
+		c.events <- event
+	}
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+		} else {
+			t.c <- c.now
+		}
+	}
+	c.timers = pending
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	defer t.clock.report("stop")
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	for i, other := range t.clock.timers {
+		if other == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func TestDelayQueueTryNext(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	dq := NewDelayQueue[string](clock)
+	start := clock.Now()
+
+	dq.Schedule("c", start.Add(3*time.Second))
+	dq.Schedule("a", start.Add(1*time.Second))
+	b := dq.Schedule("b", start.Add(2*time.Second))
+	dq.Schedule("a2", start.Add(1*time.Second))
+
+	if _, ok := dq.TryNext(); ok {
+		t.Errorf("no value should be due yet")
+	}
+	if !dq.Cancel(b) {
+		t.Errorf("cancel of a waiting value should have succeeded")
+	}
+	if dq.Cancel(b) {
+		t.Errorf("cancel of an already cancelled value should have failed")
+	}
+
+	clock.Advance(5 * time.Second)
+	for _, want := range []string{"a", "a2", "c"} {
+		if v, ok := dq.TryNext(); !ok || v != want {
+			t.Errorf("wrong data: got %q want %q", v, want)
+		}
+	}
+	if s := dq.Size(); s != 0 {
+		t.Errorf("wrong size: got %d want %d", s, 0)
+	}
+}
+
+func TestDelayQueueNext(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	dq := NewDelayQueue[int](clock)
+	ctx := context.Background()
+
+	got := make(chan int)
+	go func() {
+		for i := 0; i < 3; i++ {
+			v, err := dq.Next(ctx)
+			if err != nil {
+				t.Errorf("error while waiting: %v", err)
+			}
+			got <- v
+		}
+	}()
+
+	// Schedule while Next is already waiting on an empty queue.
+	dq.Schedule(2, clock.Now().Add(20*time.Second))
+	dq.Schedule(1, clock.Now().Add(10*time.Second))
+	dq.Schedule(3, clock.Now().Add(30*time.Second))
+
+	for want := 1; want <= 3; want++ {
+		select {
+		case v := <-got:
+			t.Fatalf("value %d released before being due", v)
+		default:
+		}
+		clock.Advance(10 * time.Second)
+		if v := <-got; v != want {
+			t.Errorf("wrong data: got %d want %d", v, want)
+		}
+	}
+}
+
+func TestDelayQueueNextCancel(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	dq := NewDelayQueue[int](clock)
+	dq.Schedule(1, clock.Now().Add(time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := dq.Next(ctx)
+		done <- err
+	}()
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("wrong error: got %v want %v", err, context.Canceled)
+	}
+}
+
+func TestDelayQueueSingleTimer(t *testing.T) {
+	// The events are buffered, so that nothing waits on the test to read
+	// them, while still telling it what the goroutines are doing.
+	clock := &fakeClock{now: time.Unix(0, 0), events: make(chan string, 100)}
+	dq := NewDelayQueue[int](clock)
+	for i := 1; i <= 3; i++ {
+		dq.Schedule(i, time.Unix(0, 0).Add(time.Duration(i)*10*time.Second))
+	}
+	expect := func(want ...string) {
+		t.Helper()
+		for _, w := range want {
+			if e := <-clock.events; e != w {
+				t.Fatalf("wrong clock event: got %q want %q", e, w)
+			}
+		}
+	}
+
+	// The first goroutine becomes the leader and waits with a timer.
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := dq.Next(leaderCtx)
+		done <- err
+	}()
+	expect("now", "timer")
+
+	// The others only read the time, which they do while holding the lock
+	// of the queue, before waiting without a timer.
+	got := make(chan int)
+	for i := 0; i < 3; i++ {
+		go func() {
+			v, err := dq.Next(context.Background())
+			if err != nil {
+				t.Errorf("error while waiting: %v", err)
+			}
+			got <- v
+		}()
+	}
+	expect("now", "now", "now")
+
+	// When the leader leaves, exactly one of the others takes its place.
+	cancel()
+	expect("stop", "now", "timer")
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("wrong error: got %v want %v", err, context.Canceled)
+	}
+	select {
+	case e := <-clock.events:
+		t.Errorf("unexpected clock event %q: only the leader should act", e)
+	default:
+	}
+
+	for want := 1; want <= 3; want++ {
+		clock.Advance(10 * time.Second)
+		if v := <-got; v != want {
+			t.Errorf("wrong data: got %d want %d", v, want)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package priorityqueue
+
+import (
+	"container/heap"
+	"fmt"
+)
+
+// HeapAdapter exposes the storage of a MinPQ through heap.Interface, so that
+// the functions of the container/heap package can operate on it directly.
+//
+// Elements are exchanged as Item values: Push accepts either an Item or an
+// int, that is used both as value and as priority, while Pop returns an Item.
+type HeapAdapter struct {
+	pq *MinPQ
+}
+
+// HeapAdapter returns an adapter that implements heap.Interface over the
+// storage of the PQ. Since container/heap only handles binary heaps, the PQ
+// must have the default arity.
+func (pq *MinPQ) HeapAdapter() HeapAdapter {
+	pq.lazyInit()
+	if pq.heap.arity != 2 {
+		panic("container/heap requires a PQ with arity 2")
+	}
+	return HeapAdapter{pq: pq}
+}
+
+// Len returns the number of elements that are into the PQ.
+func (h HeapAdapter) Len() int {
+	return h.pq.Size()
+}
+
+// Less reports whether the element at index `i` comes before the element at
+// index `j`.
+func (h HeapAdapter) Less(i, j int) bool {
+	return h.pq.heap.less(h.pq.heap.data[i], h.pq.heap.data[j])
+}
+
+// Swap swaps the elements at indexes `i` and `j`.
+func (h HeapAdapter) Swap(i, j int) {
+	h.pq.heap.swap(i, j)
+}
+
+// Push appends `x` at the end of the storage. It is meant to be called by
+// heap.Push only.
+func (h HeapAdapter) Push(x any) {
+	switch v := x.(type) {
+	case Item:
+		h.pq.heap.pushLast(h.pq.newEntry(v.Value, v.Priority))
+	case int:
+		h.pq.heap.pushLast(h.pq.newEntry(v, v))
+	default:
+		panic(fmt.Sprintf("cannot push a value of type %T into a MinPQ", x))
+	}
+}
+
+// Pop removes the last element of the storage and returns it as an Item. It
+// is meant to be called by heap.Pop and heap.Remove only.
+func (h HeapAdapter) Pop() any {
+	e := h.pq.heap.popLast()
+	h.pq.unindex(e)
+	return e.Item
+}
+
+// At returns the element at index `i`.
+func (h HeapAdapter) At(i int) Item {
+	return h.pq.heap.data[i].Item
+}
+
+// Set replaces the element at index `i` with `it`. The heap invariant must
+// then be restored with heap.Fix.
+func (h HeapAdapter) Set(i int, it Item) {
+	h.pq.unindex(h.pq.heap.data[i])
+	h.pq.heap.data[i].Item = it
+	if h.pq.heap.moved != nil {
+		h.pq.heap.moved(h.pq.heap.data[i], i)
+	}
+}
+
+// HeapWrapper provides the helpers of MinPQ on top of any heap.Interface.
+type HeapWrapper[T comparable] struct {
+	h  heap.Interface
+	at func(i int) T
+}
+
+// WrapHeap returns a new HeapWrapper over `h`, whose elements are read with
+// `at`. The elements of `h` are rearranged to satisfy the heap invariant.
+//
+// Complexity: O(n)
+func WrapHeap[T comparable](h heap.Interface, at func(i int) T) HeapWrapper[T] {
+	heap.Init(h)
+	return HeapWrapper[T]{h: h, at: at}
+}
+
+// Size returns the number of elements that are into the heap.
+//
+// Complexity: O(1)
+func (w HeapWrapper[T]) Size() int {
+	return w.h.Len()
+}
+
+// IsEmpty returns whether the heap is empty or not.
+//
+// Complexity: O(1)
+func (w HeapWrapper[T]) IsEmpty() bool {
+	return w.Size() == 0
+}
+
+// Add adds a new element with the specified `value` to the heap.
+//
+// Complexity: O(log(n))
+func (w HeapWrapper[T]) Add(value T) {
+	heap.Push(w.h, value)
+}
+
+// RemoveMin removes an element from the heap, following the priority order,
+// and returns its value.
+//
+// Complexity: O(log(n))
+func (w HeapWrapper[T]) RemoveMin() T {
+	if w.IsEmpty() {
+		panic("RemoveMin: cannot remove from an empty heap")
+	}
+	return heap.Pop(w.h).(T)
+}
+
+// Peek returns the value of the next element that would be returned by
+// RemoveMin.
+//
+// Complexity: O(1)
+func (w HeapWrapper[T]) Peek() T {
+	if w.IsEmpty() {
+		panic("Peek: cannot peek from an empty heap")
+	}
+	return w.at(0)
+}
+
+// Contains returns whether the heap contains the specified `value` or not.
+//
+// Complexity: O(n)
+func (w HeapWrapper[T]) Contains(value T) bool {
+	return w.index(value) >= 0
+}
+
+// RemoveFirstOccurrence removes the first occurrence of the specified `value`.
+//
+// Complexity: O(n)
+func (w HeapWrapper[T]) RemoveFirstOccurrence(value T) error {
+	i := w.index(value)
+	if i < 0 {
+		return fmt.Errorf("cannot remove value %v not in heap", value)
+	}
+	heap.Remove(w.h, i)
+	return nil
+}
+
+func (w HeapWrapper[T]) index(value T) int {
+	for i := 0; i < w.Size(); i++ {
+		if w.at(i) == value {
+			return i
+		}
+	}
+	return -1
+}
+
+func (w HeapWrapper[T]) String() string {
+	res := "[ "
+	for i := 0; i < w.Size(); i++ {
+		res += fmt.Sprintf("%v", w.at(i))
+		res += " "
+	}
+	return res + "]"
+}
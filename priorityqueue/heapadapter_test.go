@@ -0,0 +1,80 @@
+package priorityqueue
+
+import (
+	"container/heap"
+	"testing"
+)
+
+func TestHeapAdapter(t *testing.T) {
+	pq := New()
+	h := pq.HeapAdapter()
+
+	for _, v := range []int{5, 2, 8, 1, 9, 3} {
+		heap.Push(h, v)
+	}
+	heap.Push(h, Item{Value: 42, Priority: 4})
+
+	// Move 9 to the front, then remove 42 wherever it is.
+	for i := 0; i < h.Len(); i++ {
+		if h.At(i).Value == 9 {
+			h.Set(i, Item{Value: 9, Priority: 0})
+			heap.Fix(h, i)
+			break
+		}
+	}
+	for i := 0; i < h.Len(); i++ {
+		if h.At(i).Value == 42 {
+			if it := heap.Remove(h, i).(Item); it.Value != 42 {
+				t.Errorf("wrong removed item: got %v want %d", it, 42)
+			}
+			break
+		}
+	}
+
+	if it := heap.Pop(h).(Item); it.Value != 9 {
+		t.Errorf("wrong data: got %d want %d", it.Value, 9)
+	}
+	// The MinPQ must still be consistent after container/heap operations.
+	for _, want := range []int{1, 2, 3, 5, 8} {
+		if v := pq.RemoveMin(); v != want {
+			t.Errorf("wrong data: got %d want %d", v, want)
+		}
+	}
+}
+
+type intHeap []int
+
+func (h intHeap) Len() int           { return len(h) }
+func (h intHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+func TestWrapHeap(t *testing.T) {
+	h := &intHeap{5, 2, 4, 1}
+	w := WrapHeap(h, func(i int) int { return (*h)[i] })
+
+	w.Add(3)
+	if v := w.Peek(); v != 1 {
+		t.Errorf("wrong data: got %d want %d", v, 1)
+	}
+	if !w.Contains(4) {
+		t.Errorf("the heap must contains `%d`, but was not found", 4)
+	}
+	if err := w.RemoveFirstOccurrence(4); err != nil {
+		t.Errorf("error while removing valid value %d", 4)
+	}
+	if err := w.RemoveFirstOccurrence(4); err == nil {
+		t.Errorf("remove of a missing value should have returned an error")
+	}
+	for _, want := range []int{1, 2, 3, 5} {
+		if v := w.RemoveMin(); v != want {
+			t.Errorf("wrong data: got %d want %d", v, want)
+		}
+	}
+}
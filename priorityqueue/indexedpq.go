@@ -0,0 +1,191 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// indexedEntry is an element of the IndexedPQ: an identifier together with
+// its priority.
+type indexedEntry[K comparable, P any] struct {
+	id   K
+	prio P
+}
+
+// IndexedPQ is a priority queue whose elements are identified by a unique
+// ID, so that the priority of an element already in the PQ can be changed
+// or the element removed without knowing where it is inside the heap.
+//
+// The position of every ID is kept in a map that is updated on every swap.
+// The zero value is not ready to use, as it has neither ordering nor map:
+// use NewIndexed or NewIndexedMin.
+type IndexedPQ[K comparable, P any] struct {
+	heap PriorityQueue[indexedEntry[K, P]]
+	less func(a, b P) bool
+	pos  map[K]int
+}
+
+// NewIndexed returns a new IndexedPQ instance ordered by `less` applied on
+// the priorities.
+func NewIndexed[K comparable, P any](less func(a, b P) bool, opts ...Option) IndexedPQ[K, P] {
+	if less == nil {
+		panic("less function must not be nil")
+	}
+	pos := make(map[K]int)
+	heap := NewPriorityQueue(func(a, b indexedEntry[K, P]) bool {
+		return less(a.prio, b.prio)
+	}, opts...)
+	heap.moved = func(e indexedEntry[K, P], i int) {
+		pos[e.id] = i
+	}
+	return IndexedPQ[K, P]{heap: heap, less: less, pos: pos}
+}
+
+// NewIndexedMin returns a new IndexedPQ instance that removes the ID with
+// the least priority first, according to the natural ordering of `P`.
+func NewIndexedMin[K comparable, P cmp.Ordered](opts ...Option) IndexedPQ[K, P] {
+	return NewIndexed[K](cmp.Less[P], opts...)
+}
+
+// Size returns the number of elements that are into the PQ.
+//
+// Complexity: O(1)
+func (pq *IndexedPQ[K, P]) Size() int {
+	return pq.heap.Size()
+}
+
+// IsEmpty returns whether the PQ is empty or not.
+//
+// Complexity: O(1)
+func (pq *IndexedPQ[K, P]) IsEmpty() bool {
+	return pq.Size() == 0
+}
+
+// ContainsID returns whether the PQ contains an element with the specified
+// `id` or not.
+//
+// Complexity: O(1)
+func (pq *IndexedPQ[K, P]) ContainsID(id K) bool {
+	_, ok := pq.pos[id]
+	return ok
+}
+
+// Priority returns the priority of the element with the specified `id`, if
+// it is into the PQ.
+//
+// Complexity: O(1)
+func (pq *IndexedPQ[K, P]) Priority(id K) (P, bool) {
+	i, ok := pq.pos[id]
+	if !ok {
+		var zero P
+		return zero, false
+	}
+	return pq.heap.data[i].prio, true
+}
+
+// Insert adds a new element with the specified `id` and priority `prio` to
+// the PQ. It returns an error if the ID is already present.
+//
+// Complexity: O(log(n))
+func (pq *IndexedPQ[K, P]) Insert(id K, prio P) error {
+	if pq.ContainsID(id) {
+		return fmt.Errorf("cannot insert id %v already in PQ", id)
+	}
+	pq.heap.Add(indexedEntry[K, P]{id: id, prio: prio})
+	return nil
+}
+
+// Peek returns the ID and the priority of the next element that would be
+// returned by Remove.
+//
+// Complexity: O(1)
+func (pq *IndexedPQ[K, P]) Peek() (K, P) {
+	if pq.IsEmpty() {
+		panic("Peek: cannot peek from an empty PQ")
+	}
+	e := pq.heap.Peek()
+	return e.id, e.prio
+}
+
+// Remove removes an element from the PQ, following the priority order, and
+// returns its ID and priority.
+//
+// Complexity: O(log(n))
+func (pq *IndexedPQ[K, P]) Remove() (K, P) {
+	if pq.IsEmpty() {
+		panic("Remove: cannot remove from an empty PQ")
+	}
+	e := pq.heap.Remove()
+	delete(pq.pos, e.id)
+	return e.id, e.prio
+}
+
+// Delete removes the element with the specified `id` from the PQ.
+//
+// Complexity: O(log(n))
+func (pq *IndexedPQ[K, P]) Delete(id K) error {
+	i, ok := pq.pos[id]
+	if !ok {
+		return fmt.Errorf("cannot delete id %v not in PQ", id)
+	}
+	pq.heap.removeAt(i)
+	delete(pq.pos, id)
+	return nil
+}
+
+// DecreaseKey changes the priority of the element with the specified `id`
+// to `prio`, which must come before the current one in the PQ order.
+//
+// Complexity: O(log(n))
+func (pq *IndexedPQ[K, P]) DecreaseKey(id K, prio P) error {
+	i, ok := pq.pos[id]
+	if !ok {
+		return fmt.Errorf("cannot decrease key of id %v not in PQ", id)
+	}
+	if pq.less(pq.heap.data[i].prio, prio) {
+		return fmt.Errorf("cannot decrease key of id %v: new priority %v comes after %v", id, prio, pq.heap.data[i].prio)
+	}
+	pq.heap.data[i].prio = prio
+	pq.heap.bubbleUp(i)
+	return nil
+}
+
+// IncreaseKey changes the priority of the element with the specified `id`
+// to `prio`, which must come after the current one in the PQ order.
+//
+// Complexity: O(log(n))
+func (pq *IndexedPQ[K, P]) IncreaseKey(id K, prio P) error {
+	i, ok := pq.pos[id]
+	if !ok {
+		return fmt.Errorf("cannot increase key of id %v not in PQ", id)
+	}
+	if pq.less(prio, pq.heap.data[i].prio) {
+		return fmt.Errorf("cannot increase key of id %v: new priority %v comes before %v", id, prio, pq.heap.data[i].prio)
+	}
+	pq.heap.data[i].prio = prio
+	pq.heap.bubbleDown(i)
+	return nil
+}
+
+// Update changes the priority of the element with the specified `id` to
+// `prio`, in whichever direction.
+//
+// Complexity: O(log(n))
+func (pq *IndexedPQ[K, P]) Update(id K, prio P) error {
+	i, ok := pq.pos[id]
+	if !ok {
+		return fmt.Errorf("cannot update id %v not in PQ", id)
+	}
+	pq.heap.data[i].prio = prio
+	pq.heap.fix(i)
+	return nil
+}
+
+func (pq IndexedPQ[K, P]) String() string {
+	res := "[ "
+	for _, e := range pq.heap.data {
+		res += fmt.Sprintf("%v:%v", e.id, e.prio)
+		res += " "
+	}
+	return res + "]"
+}
@@ -0,0 +1,63 @@
+package priorityqueue
+
+import "testing"
+
+func TestIndexedPQInsert(t *testing.T) {
+	pq := NewIndexedMin[string, int]()
+
+	if err := pq.Insert("a", 3); err != nil {
+		t.Errorf("insert returned error, but should not")
+	}
+	if err := pq.Insert("a", 1); err == nil {
+		t.Errorf("insert should have returned an error")
+	}
+	if !pq.ContainsID("a") {
+		t.Errorf("the PQ must contains `%s`, but was not found", "a")
+	}
+	if pq.ContainsID("b") {
+		t.Errorf("the PQ does not contains `%s`, but was found", "b")
+	}
+}
+
+func TestIndexedPQChangeKey(t *testing.T) {
+	pq := NewIndexedMin[int, int]()
+
+	for id := 0; id < 10; id++ {
+		pq.Insert(id, 100+id)
+	}
+
+	if err := pq.DecreaseKey(7, 1); err != nil {
+		t.Errorf("error while decreasing key of %d: %v", 7, err)
+	}
+	if err := pq.DecreaseKey(3, 500); err == nil {
+		t.Errorf("decrease key to a bigger priority should have returned an error")
+	}
+	if err := pq.IncreaseKey(0, 200); err != nil {
+		t.Errorf("error while increasing key of %d: %v", 0, err)
+	}
+	if err := pq.IncreaseKey(5, 0); err == nil {
+		t.Errorf("increase key to a smaller priority should have returned an error")
+	}
+	if err := pq.Update(9, 2); err != nil {
+		t.Errorf("error while updating %d: %v", 9, err)
+	}
+	if err := pq.Delete(4); err != nil {
+		t.Errorf("error while deleting %d: %v", 4, err)
+	}
+	if err := pq.Delete(4); err == nil {
+		t.Errorf("delete of a missing id should have returned an error")
+	}
+	if p, ok := pq.Priority(7); !ok || p != 1 {
+		t.Errorf("wrong priority: got %d want %d", p, 1)
+	}
+
+	want := []int{7, 9, 1, 2, 3, 5, 6, 8, 0}
+	for _, w := range want {
+		if id, _ := pq.Remove(); id != w {
+			t.Errorf("wrong id: got %d want %d", id, w)
+		}
+	}
+	if !pq.IsEmpty() || pq.ContainsID(7) {
+		t.Errorf("the PQ should be empty, but it is not")
+	}
+}
@@ -0,0 +1,75 @@
+package priorityqueue
+
+// iteratorFunc turns a function into an Iterator.
+type iteratorFunc[T any] func() (T, bool)
+
+func (f iteratorFunc[T]) Next() (T, bool) {
+	return f()
+}
+
+// Ordered returns an Iterator over the elements of the PQ in priority order,
+// that is the order in which Remove would return them, without modifying
+// the PQ. The PQ must not be modified while iterating.
+//
+// The iterator keeps an auxiliary heap with the indexes of the elements that
+// can come next: the children of the ones already yielded.
+//
+// Complexity: O(k*log(k)) for the first k elements
+func (pq *PriorityQueue[T]) Ordered() Iterator[T] {
+	frontier := NewPriorityQueue(func(i, j int) bool {
+		return pq.less(pq.data[i], pq.data[j])
+	})
+	if !pq.IsEmpty() {
+		frontier.Add(0)
+	}
+
+	return iteratorFunc[T](func() (T, bool) {
+		if frontier.IsEmpty() {
+			var zero T
+			return zero, false
+		}
+		i := frontier.Remove()
+		first := pq.firstChild(i)
+		last := min(first+pq.arity, pq.Size())
+		for c := first; c < last; c++ {
+			frontier.Add(c)
+		}
+		return pq.data[i], true
+	})
+}
+
+// Ordered returns an Iterator over the values of the PQ in priority order,
+// that is the order in which RemoveMin would return them, without modifying
+// the PQ. The PQ must not be modified while iterating.
+//
+// Complexity: O(k*log(k)) for the first k elements
+func (pq *MinPQ) Ordered() Iterator[int] {
+	it := pq.heap.Ordered()
+	return iteratorFunc[int](func() (int, bool) {
+		e, ok := it.Next()
+		return e.Value, ok
+	})
+}
+
+// OrderedItems returns an Iterator over the values and the priorities of the
+// PQ in priority order, that is the order in which RemoveMinItem would return
+// them, without modifying the PQ. The PQ must not be modified while
+// iterating.
+//
+// Complexity: O(k*log(k)) for the first k elements
+func (pq *MinPQ) OrderedItems() Iterator[Item] {
+	it := pq.heap.Ordered()
+	return iteratorFunc[Item](func() (Item, bool) {
+		e, ok := it.Next()
+		return e.Item, ok
+	})
+}
+
+// Ordered returns an Iterator over the values of the PQ in priority order,
+// that is the order in which RemoveMax would return them, without modifying
+// the PQ. The PQ must not be modified while iterating.
+//
+// Complexity: O(k*log(k)) for the first k elements
+func (pq *MaxPQ) Ordered() Iterator[int] {
+	return pq.heap.Ordered()
+}
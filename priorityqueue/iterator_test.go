@@ -0,0 +1,56 @@
+package priorityqueue
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestOrdered(t *testing.T) {
+	r := rand.New(rand.NewSource(37))
+	for _, d := range []int{2, 3, 8} {
+		pq := New(WithArity(d))
+		var want []int
+		for i := 0; i < 300; i++ {
+			v := r.Intn(100)
+			pq.Add(v)
+			want = append(want, v)
+		}
+		sort.Ints(want)
+		before := pq.String()
+
+		it := pq.Ordered()
+		for i, w := range want {
+			v, ok := it.Next()
+			if !ok || v != w {
+				t.Fatalf("arity %d: wrong data at %d: got %d want %d", d, i, v, w)
+			}
+		}
+		if _, ok := it.Next(); ok {
+			t.Errorf("arity %d: the iterator should be exhausted", d)
+		}
+		if pq.String() != before || pq.Size() != len(want) {
+			t.Errorf("arity %d: the PQ should not be modified by iterating", d)
+		}
+	}
+}
+
+func TestOrderedItems(t *testing.T) {
+	pq := New(Stable())
+	pq.AddWithPriority(1, 5)
+	pq.AddWithPriority(2, 1)
+	pq.AddWithPriority(3, 5)
+	pq.AddWithPriority(4, 0)
+
+	it := pq.OrderedItems()
+	for _, want := range []Item{{4, 0}, {2, 1}, {1, 5}, {3, 5}} {
+		if got, _ := it.Next(); got != want {
+			t.Errorf("wrong item: got %v want %v", got, want)
+		}
+	}
+
+	empty := New()
+	if _, ok := empty.Ordered().Next(); ok {
+		t.Errorf("the iterator of an empty PQ should be exhausted")
+	}
+}
@@ -0,0 +1,88 @@
+package priorityqueue
+
+import "fmt"
+
+// MaxPQ is an abstract data type (ADT) that works in the same way as a Queue
+// but every element has a priority that determines the order in which it is
+// extracted.
+//
+// This implementation removes the element with the greatest priority first.
+//
+// The zero value is an empty MaxPQ ready to use, as returned by NewMax
+// without options.
+type MaxPQ struct {
+	heap PriorityQueue[int]
+}
+
+// NewMax returns a new MaxPQ instance.
+func NewMax(opts ...Option) MaxPQ {
+	return MaxPQ{heap: NewMaxOrdered[int](opts...)}
+}
+
+// Size returns the number of elements that are into the PQ.
+//
+// Complexity: O(1)
+func (pq *MaxPQ) Size() int {
+	return pq.heap.Size()
+}
+
+// IsEmpty returns whether the PQ is empty or not.
+//
+// Complexity: O(1)
+func (pq *MaxPQ) IsEmpty() bool {
+	return pq.Size() == 0
+}
+
+// Add adds a new element with the specified `value` to the PQ.
+//
+// Complexity: O(log(n))
+func (pq *MaxPQ) Add(value int) {
+	if pq.heap.less == nil {
+		pq.heap = NewMaxOrdered[int]()
+	}
+	pq.heap.Add(value)
+}
+
+// RemoveMax removes an element from the PQ, following the priority order, and
+// returns its value.
+//
+// Complexity: O(log(n))
+func (pq *MaxPQ) RemoveMax() int {
+	if pq.IsEmpty() {
+		panic("RemoveMax: cannot remove from an empty PQ")
+	}
+	return pq.heap.Remove()
+}
+
+// Peek returns the value of the next element that would be returned by
+// RemoveMax.
+//
+// Complexity: O(1)
+func (pq *MaxPQ) Peek() int {
+	if pq.IsEmpty() {
+		panic("Peek: cannot peek from an empty PQ")
+	}
+	return pq.heap.Peek()
+}
+
+// Contains returns whether the PQ contains the specified `value` or not.
+//
+// Complexity: O(n)
+func (pq *MaxPQ) Contains(value int) bool {
+	return pq.heap.ContainsFunc(func(v int) bool { return v == value })
+}
+
+// RemoveFirstOccurrence removes the first occurrence of the specified `value`.
+//
+// Complexity: O(n)
+func (pq *MaxPQ) RemoveFirstOccurrence(value int) error {
+	_, err := pq.heap.RemoveFirstFunc(func(v int) bool { return v == value })
+	if err != nil {
+		return fmt.Errorf("cannot remove value %d not in PQ", value)
+	}
+	return nil
+}
+
+func (pq MaxPQ) String() string {
+	return pq.heap.String()
+}
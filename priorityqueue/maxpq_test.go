@@ -0,0 +1,76 @@
+package priorityqueue
+
+import "testing"
+
+func TestMaxPQRemoveMax(t *testing.T) {
+	pq := NewMax()
+
+	pq.Add(2)
+	pq.Add(5)
+	pq.Add(4)
+	pq.Add(1)
+	pq.Add(3)
+
+	if v := pq.Peek(); v != 5 {
+		t.Errorf("wrong data: got %d want %d", v, 5)
+	}
+	for i := 5; !pq.IsEmpty(); i-- {
+		if v := pq.RemoveMax(); v != i {
+			t.Errorf("wrong data: got %d want %d", v, i)
+		}
+	}
+}
+
+func TestMaxPQZeroValue(t *testing.T) {
+	var pq MaxPQ
+
+	pq.Add(1)
+	pq.Add(3)
+	pq.Add(2)
+
+	for i := 3; !pq.IsEmpty(); i-- {
+		if v := pq.RemoveMax(); v != i {
+			t.Errorf("wrong data: got %d want %d", v, i)
+		}
+	}
+}
+
+func TestMaxPQRemoveFirstOccurrence(t *testing.T) {
+	pq := NewMax()
+
+	for i := 1; i <= 11; i++ {
+		pq.Add(i)
+	}
+
+	for i := 2; i <= 10; i += 2 {
+		if err := pq.RemoveFirstOccurrence(i); err != nil {
+			t.Errorf("error while removing valid value %d", i)
+		}
+	}
+	if pq.Contains(4) {
+		t.Errorf("the PQ does not contains `%d`, but was found", 4)
+	}
+	for i := 11; !pq.IsEmpty(); i -= 2 {
+		if v := pq.RemoveMax(); v != i {
+			t.Errorf("wrong data: got %d want %d", v, i)
+		}
+	}
+}
+
+func TestMaxPQOrdered(t *testing.T) {
+	pq := NewMax()
+
+	for _, v := range []int{2, 5, 4, 1, 3} {
+		pq.Add(v)
+	}
+
+	it := pq.Ordered()
+	for i := 5; i >= 1; i-- {
+		if v, _ := it.Next(); v != i {
+			t.Errorf("wrong data: got %d want %d", v, i)
+		}
+	}
+	if pq.Size() != 5 {
+		t.Errorf("the PQ should not be modified by iterating")
+	}
+}
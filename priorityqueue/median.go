@@ -0,0 +1,94 @@
+package priorityqueue
+
+import "fmt"
+
+// MedianTracker keeps track of the median of a multiset of values that
+// changes over time, such as a sliding window over a stream.
+//
+// The smaller half of the values is kept in a max heap and the bigger half
+// in a min heap, so that the median is always at the top of them. Both heaps
+// have a value index to remove arbitrary values quickly.
+type MedianTracker struct {
+	// lower is ordered by the complement of the values, that reverses their
+	// order without overflowing like their opposite, so that its first
+	// element is the greatest value of the smaller half.
+	lower MinPQ
+	upper MinPQ
+}
+
+// NewMedianTracker returns a new MedianTracker instance.
+func NewMedianTracker() MedianTracker {
+	return MedianTracker{
+		lower: New(WithValueIndex()),
+		upper: New(WithValueIndex()),
+	}
+}
+
+// Size returns the number of values that are tracked.
+//
+// Complexity: O(1)
+func (mt *MedianTracker) Size() int {
+	return mt.lower.Size() + mt.upper.Size()
+}
+
+// IsEmpty returns whether there is any value tracked or not.
+//
+// Complexity: O(1)
+func (mt *MedianTracker) IsEmpty() bool {
+	return mt.Size() == 0
+}
+
+// Add adds the specified `value` to the tracked ones.
+//
+// Complexity: O(log(n))
+func (mt *MedianTracker) Add(value int) {
+	if mt.lower.IsEmpty() || value <= mt.lower.Peek() {
+		mt.lower.AddWithPriority(value, ^value)
+	} else {
+		mt.upper.Add(value)
+	}
+	mt.rebalance()
+}
+
+// Remove removes an occurrence of the specified `value`, that must have been
+// previously added, from the tracked ones.
+//
+// Complexity: O(log(n))
+func (mt *MedianTracker) Remove(value int) error {
+	// Equal values may be split between the two halves, so look into the
+	// other one if the value is not where expected.
+	first, second := &mt.upper, &mt.lower
+	if !mt.lower.IsEmpty() && value <= mt.lower.Peek() {
+		first, second = second, first
+	}
+	if first.RemoveFirstOccurrence(value) != nil && second.RemoveFirstOccurrence(value) != nil {
+		return fmt.Errorf("cannot remove value %d not tracked", value)
+	}
+	mt.rebalance()
+	return nil
+}
+
+// Median returns the median of the tracked values: the middle one if their
+// number is odd, or the mean of the two middle ones if it is even.
+//
+// Complexity: O(1)
+func (mt *MedianTracker) Median() float64 {
+	if mt.IsEmpty() {
+		panic("Median: cannot compute the median of no values")
+	}
+	if mt.lower.Size() > mt.upper.Size() {
+		return float64(mt.lower.Peek())
+	}
+	return (float64(mt.lower.Peek()) + float64(mt.upper.Peek())) / 2
+}
+
+// rebalance moves the top of one half to the other one, so that the lower
+// half has either the same number of values of the upper half or one more.
+func (mt *MedianTracker) rebalance() {
+	if mt.lower.Size() > mt.upper.Size()+1 {
+		mt.upper.Add(mt.lower.RemoveMin())
+	} else if mt.upper.Size() > mt.lower.Size() {
+		v := mt.upper.RemoveMin()
+		mt.lower.AddWithPriority(v, ^v)
+	}
+}
@@ -0,0 +1,82 @@
+package priorityqueue
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func sortedMedian(values []int) float64 {
+	s := append([]int(nil), values...)
+	sort.Ints(s)
+	if len(s)%2 == 1 {
+		return float64(s[len(s)/2])
+	}
+	return (float64(s[len(s)/2-1]) + float64(s[len(s)/2])) / 2
+}
+
+func TestMedianTrackerAdd(t *testing.T) {
+	r := rand.New(rand.NewSource(29))
+	mt := NewMedianTracker()
+	var values []int
+
+	for i := 0; i < 500; i++ {
+		v := r.Intn(100) - 50
+		mt.Add(v)
+		values = append(values, v)
+		if got, want := mt.Median(), sortedMedian(values); got != want {
+			t.Fatalf("step %d: wrong median: got %v want %v", i, got, want)
+		}
+	}
+}
+
+func TestMedianTrackerSlidingWindow(t *testing.T) {
+	r := rand.New(rand.NewSource(31))
+	mt := NewMedianTracker()
+	const window = 25
+	var stream []int
+
+	for i := 0; i < 2000; i++ {
+		v := r.Intn(40)
+		stream = append(stream, v)
+		mt.Add(v)
+		if len(stream) > window {
+			if err := mt.Remove(stream[len(stream)-window-1]); err != nil {
+				t.Fatalf("step %d: error while removing: %v", i, err)
+			}
+		}
+
+		start := max(0, len(stream)-window)
+		if got, want := mt.Median(), sortedMedian(stream[start:]); got != want {
+			t.Fatalf("step %d: wrong median: got %v want %v", i, got, want)
+		}
+	}
+
+	if err := mt.Remove(1000); err == nil {
+		t.Errorf("remove of a value never added should have returned an error")
+	}
+}
+
+func TestMedianTrackerExtremes(t *testing.T) {
+	mt := NewMedianTracker()
+
+	mt.Add(5)
+	mt.Add(math.MinInt)
+	mt.Add(7)
+	if m := mt.Median(); m != 5 {
+		t.Errorf("wrong median: got %v want %v", m, 5)
+	}
+
+	mt.Add(math.MaxInt)
+	mt.Add(math.MinInt)
+	if m := mt.Median(); m != 5 {
+		t.Errorf("wrong median: got %v want %v", m, 5)
+	}
+	if err := mt.Remove(math.MinInt); err != nil {
+		t.Errorf("error while removing %d: %v", math.MinInt, err)
+	}
+	if m := mt.Median(); m != 6 {
+		t.Errorf("wrong median: got %v want %v", m, 6)
+	}
+}
@@ -0,0 +1,111 @@
+package priorityqueue
+
+// Iterator produces a sequence of values, one at a time.
+type Iterator[T any] interface {
+	// Next returns the next value of the sequence and true, or false if the
+	// sequence is over.
+	Next() (T, bool)
+}
+
+type sliceIterator[T any] struct {
+	data []T
+}
+
+// SliceIterator returns an Iterator over the elements of `data`.
+func SliceIterator[T any](data []T) Iterator[T] {
+	return &sliceIterator[T]{data: data}
+}
+
+func (it *sliceIterator[T]) Next() (T, bool) {
+	if len(it.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	res := it.data[0]
+	it.data = it.data[1:]
+	return res, true
+}
+
+// mergeHead is the current value of one of the sequences being merged.
+type mergeHead[T any] struct {
+	value T
+	src   int
+}
+
+// MergeK merges the sequences `its`, each sorted according to `less`, into a
+// single sorted sequence, calling `yield` on every value in order. Equal
+// values are yielded in the order of the sequences they come from. Merging
+// stops as soon as `yield` returns false.
+//
+// Only the current value of every sequence is kept, into a heap of size k.
+//
+// Complexity: O(n*log(k))
+func MergeK[T any](its []Iterator[T], less func(a, b T) bool, yield func(T) bool) {
+	heap := NewPriorityQueue(func(a, b mergeHead[T]) bool {
+		if less(a.value, b.value) {
+			return true
+		}
+		if less(b.value, a.value) {
+			return false
+		}
+		return a.src < b.src
+	})
+
+	heads := make([]mergeHead[T], 0, len(its))
+	for i, it := range its {
+		if v, ok := it.Next(); ok {
+			heads = append(heads, mergeHead[T]{value: v, src: i})
+		}
+	}
+	heap.data = heads
+	heap.heapify()
+
+	for !heap.IsEmpty() {
+		head := heap.Peek()
+		if !yield(head.value) {
+			return
+		}
+
+		// Replace the head with the next value of the same sequence, if any.
+		if v, ok := its[head.src].Next(); ok {
+			heap.replaceTop(mergeHead[T]{value: v, src: head.src})
+		} else {
+			heap.Remove()
+		}
+	}
+}
+
+// MergeSlices merges the slices `data`, each sorted according to `less`,
+// into a new sorted slice.
+//
+// Complexity: O(n*log(k))
+func MergeSlices[T any](data [][]T, less func(a, b T) bool) []T {
+	its := make([]Iterator[T], len(data))
+	total := 0
+	for i, s := range data {
+		its[i] = SliceIterator(s)
+		total += len(s)
+	}
+
+	res := make([]T, 0, total)
+	MergeK(its, less, func(v T) bool {
+		res = append(res, v)
+		return true
+	})
+	return res
+}
+
+// HeapSort sorts `data` in place according to `less`. The sort is not
+// stable.
+//
+// Complexity: O(n*log(n))
+func HeapSort[T any](data []T, less func(a, b T) bool) {
+	// Build a heap with the greatest element first, then repeatedly move it
+	// right after the end of the shrinking heap.
+	heap := Heapify(data, func(a, b T) bool { return less(b, a) })
+	for end := len(data) - 1; end > 0; end-- {
+		heap.swap(0, end)
+		heap.data = heap.data[:end]
+		heap.bubbleDown(0)
+	}
+}
@@ -0,0 +1,95 @@
+package priorityqueue
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestMergeSlices(t *testing.T) {
+	r := rand.New(rand.NewSource(13))
+	var data [][]int
+	var want []int
+	for k := 0; k < 20; k++ {
+		s := make([]int, r.Intn(50))
+		for i := range s {
+			s[i] = r.Intn(200)
+		}
+		sort.Ints(s)
+		data = append(data, s)
+		want = append(want, s...)
+	}
+	sort.Ints(want)
+
+	got := MergeSlices(data, intLess)
+	if len(got) != len(want) {
+		t.Fatalf("wrong length: got %d want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("wrong data at %d: got %d want %d", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMergeKEarlyStop(t *testing.T) {
+	its := []Iterator[int]{
+		SliceIterator([]int{1, 4, 7}),
+		SliceIterator([]int{}),
+		SliceIterator([]int{2, 5, 8}),
+		SliceIterator([]int{3, 6, 9}),
+	}
+
+	var got []int
+	MergeK(its, intLess, func(v int) bool {
+		got = append(got, v)
+		return v < 5
+	})
+
+	if len(got) != 5 {
+		t.Errorf("merge should have stopped after %d values, got %v", 5, got)
+	}
+	for i, v := range got {
+		if v != i+1 {
+			t.Errorf("wrong data: got %d want %d", v, i+1)
+		}
+	}
+}
+
+func TestMergeKStable(t *testing.T) {
+	its := []Iterator[job]{
+		SliceIterator([]job{{"a1", 1}, {"a2", 2}}),
+		SliceIterator([]job{{"b1", 1}, {"b2", 2}}),
+	}
+
+	var got []string
+	MergeK(its, func(a, b job) bool { return a.deadline < b.deadline }, func(j job) bool {
+		got = append(got, j.name)
+		return true
+	})
+
+	for i, want := range []string{"a1", "b1", "a2", "b2"} {
+		if got[i] != want {
+			t.Errorf("wrong data: got %q want %q", got[i], want)
+		}
+	}
+}
+
+func TestHeapSort(t *testing.T) {
+	r := rand.New(rand.NewSource(17))
+	for _, n := range []int{0, 1, 2, 3, 100, 1000} {
+		data := make([]int, n)
+		for i := range data {
+			data[i] = r.Intn(n + 1)
+		}
+		want := append([]int(nil), data...)
+		sort.Ints(want)
+
+		HeapSort(data, intLess)
+		for i := range want {
+			if data[i] != want[i] {
+				t.Fatalf("n=%d: wrong data at %d: got %d want %d", n, i, data[i], want[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package priorityqueue
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// MinMaxPQ is a double-ended priority queue: both the element with the
+// least priority and the one with the greatest priority can be extracted.
+//
+// This implementation uses an array-based min-max heap, that is a binary
+// heap whose even levels (starting from the root) are ordered as a min heap
+// and whose odd levels are ordered as a max heap.
+type MinMaxPQ struct {
+	data []int
+}
+
+// NewMinMax returns a new MinMaxPQ instance.
+func NewMinMax() MinMaxPQ {
+	return MinMaxPQ{data: make([]int, 0)}
+}
+
+// Size returns the number of elements that are into the PQ.
+//
+// Complexity: O(1)
+func (pq *MinMaxPQ) Size() int {
+	return len(pq.data)
+}
+
+// IsEmpty returns whether the PQ is empty or not.
+//
+// Complexity: O(1)
+func (pq *MinMaxPQ) IsEmpty() bool {
+	return pq.Size() == 0
+}
+
+// Add adds a new element with the specified `value` to the PQ.
+//
+// Complexity: O(log(n))
+func (pq *MinMaxPQ) Add(value int) {
+	pq.data = append(pq.data, value)
+	pq.bubbleUp(pq.Size() - 1)
+}
+
+// PeekMin returns the value of the next element that would be returned by
+// RemoveMin.
+//
+// Complexity: O(1)
+func (pq *MinMaxPQ) PeekMin() int {
+	if pq.IsEmpty() {
+		panic("PeekMin: cannot peek from an empty PQ")
+	}
+	return pq.data[0]
+}
+
+// PeekMax returns the value of the next element that would be returned by
+// RemoveMax.
+//
+// Complexity: O(1)
+func (pq *MinMaxPQ) PeekMax() int {
+	if pq.IsEmpty() {
+		panic("PeekMax: cannot peek from an empty PQ")
+	}
+	return pq.data[pq.maxIndex()]
+}
+
+// RemoveMin removes the element with the least priority from the PQ and
+// returns its value.
+//
+// Complexity: O(log(n))
+func (pq *MinMaxPQ) RemoveMin() int {
+	if pq.IsEmpty() {
+		panic("RemoveMin: cannot remove from an empty PQ")
+	}
+	return pq.removeAt(0)
+}
+
+// RemoveMax removes the element with the greatest priority from the PQ and
+// returns its value.
+//
+// Complexity: O(log(n))
+func (pq *MinMaxPQ) RemoveMax() int {
+	if pq.IsEmpty() {
+		panic("RemoveMax: cannot remove from an empty PQ")
+	}
+	return pq.removeAt(pq.maxIndex())
+}
+
+// Contains returns whether the PQ contains the specified `value` or not.
+//
+// Complexity: O(n)
+func (pq *MinMaxPQ) Contains(value int) bool {
+	for _, v := range pq.data {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// maxIndex returns the index of the element with the greatest priority, that
+// is one of the children of the root, if any.
+func (pq *MinMaxPQ) maxIndex() int {
+	switch {
+	case pq.Size() == 1:
+		return 0
+	case pq.Size() == 2 || pq.data[1] >= pq.data[2]:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// removeAt removes the root of the min heap or of the max heap, at index
+// `i`, and restores the heap invariant.
+func (pq *MinMaxPQ) removeAt(i int) int {
+	res := pq.data[i]
+	last := pq.Size() - 1
+	pq.data[i] = pq.data[last]
+	pq.data = pq.data[:last]
+	if i < last {
+		pq.bubbleDown(i)
+	}
+	return res
+}
+
+func (pq *MinMaxPQ) bubbleUp(fromIndex int) {
+	if fromIndex == 0 {
+		return
+	}
+	parent := (fromIndex - 1) / 2
+
+	// First decide whether the element belongs to the min levels or to the
+	// max levels, then bubble it up through its grandparents only.
+	if isMinLevel(fromIndex) {
+		if pq.data[fromIndex] > pq.data[parent] {
+			pq.swap(fromIndex, parent)
+			pq.bubbleUpLevels(parent, intGreater)
+		} else {
+			pq.bubbleUpLevels(fromIndex, intLess)
+		}
+	} else {
+		if pq.data[fromIndex] < pq.data[parent] {
+			pq.swap(fromIndex, parent)
+			pq.bubbleUpLevels(parent, intLess)
+		} else {
+			pq.bubbleUpLevels(fromIndex, intGreater)
+		}
+	}
+}
+
+// bubbleUpLevels bubbles up the element at `fromIndex` through the levels
+// with the same kind, using `before` to compare priorities.
+func (pq *MinMaxPQ) bubbleUpLevels(fromIndex int, before func(a, b int) bool) {
+	// Loop until a grandparent exists and the element should come before it.
+	for fromIndex > 2 {
+		grandparent := ((fromIndex-1)/2 - 1) / 2
+		if !before(pq.data[fromIndex], pq.data[grandparent]) {
+			return
+		}
+		pq.swap(fromIndex, grandparent)
+		fromIndex = grandparent
+	}
+}
+
+func (pq *MinMaxPQ) bubbleDown(fromIndex int) {
+	if isMinLevel(fromIndex) {
+		pq.bubbleDownLevels(fromIndex, intLess)
+	} else {
+		pq.bubbleDownLevels(fromIndex, intGreater)
+	}
+}
+
+// bubbleDownLevels bubbles down the element at `fromIndex` through the
+// levels with the same kind, using `before` to compare priorities.
+func (pq *MinMaxPQ) bubbleDownLevels(fromIndex int, before func(a, b int) bool) {
+	// Loop until a left child exists.
+	for (2*fromIndex)+1 < pq.Size() {
+		// Find which one among children and grandchildren comes first.
+		first := (2 * fromIndex) + 1
+		for _, i := range []int{
+			(2 * fromIndex) + 2,
+			(4 * fromIndex) + 3, (4 * fromIndex) + 4,
+			(4 * fromIndex) + 5, (4 * fromIndex) + 6,
+		} {
+			if i < pq.Size() && before(pq.data[i], pq.data[first]) {
+				first = i
+			}
+		}
+
+		if !before(pq.data[first], pq.data[fromIndex]) {
+			return
+		}
+		pq.swap(first, fromIndex)
+
+		// If it was a child, it had no descendant that comes before it, so we
+		// are done.
+		if first <= (2*fromIndex)+2 {
+			return
+		}
+
+		// A grandchild could now be on the wrong side of its parent.
+		parent := (first - 1) / 2
+		if before(pq.data[parent], pq.data[first]) {
+			pq.swap(parent, first)
+		}
+		fromIndex = first
+	}
+}
+
+func (pq *MinMaxPQ) swap(idx1, idx2 int) {
+	pq.data[idx1], pq.data[idx2] = pq.data[idx2], pq.data[idx1]
+}
+
+// isMinLevel returns whether the index `i` is on an even level of the heap.
+func isMinLevel(i int) bool {
+	return (bits.Len(uint(i+1))-1)%2 == 0
+}
+
+func intLess(a, b int) bool {
+	return a < b
+}
+
+func intGreater(a, b int) bool {
+	return a > b
+}
+
+func (pq MinMaxPQ) String() string {
+	res := "[ "
+	for _, v := range pq.data {
+		res += fmt.Sprintf("%d", v)
+		res += " "
+	}
+	return res + "]"
+}
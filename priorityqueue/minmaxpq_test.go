@@ -0,0 +1,53 @@
+package priorityqueue
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestMinMaxPQPeek(t *testing.T) {
+	pq := NewMinMax()
+
+	for _, v := range []int{2, 5, 4, 1, 3} {
+		pq.Add(v)
+	}
+
+	if v := pq.PeekMin(); v != 1 {
+		t.Errorf("wrong data: got %d want %d", v, 1)
+	}
+	if v := pq.PeekMax(); v != 5 {
+		t.Errorf("wrong data: got %d want %d", v, 5)
+	}
+	if !pq.Contains(4) {
+		t.Errorf("the PQ must contains `%d`, but was not found", 4)
+	}
+}
+
+func TestMinMaxPQRemove(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	pq := NewMinMax()
+	var want []int
+
+	for i := 0; i < 1000; i++ {
+		v := r.Intn(300)
+		pq.Add(v)
+		want = append(want, v)
+	}
+	sort.Ints(want)
+
+	// Alternate removals from both ends.
+	for !pq.IsEmpty() {
+		if r.Intn(2) == 0 {
+			if v := pq.RemoveMin(); v != want[0] {
+				t.Fatalf("wrong min: got %d want %d", v, want[0])
+			}
+			want = want[1:]
+		} else {
+			if v := pq.RemoveMax(); v != want[len(want)-1] {
+				t.Fatalf("wrong max: got %d want %d", v, want[len(want)-1])
+			}
+			want = want[:len(want)-1]
+		}
+	}
+}
@@ -7,20 +7,82 @@ import "fmt"
 // extracted.
 //
 // This implementation removes the element with the least priority first.
+// Elements added with Add use their value as priority, while AddWithPriority
+// keeps the two apart.
+//
+// The zero value is an empty MinPQ ready to use, as returned by New without
+// options.
 type MinPQ struct {
-	data []int
+	heap PriorityQueue[entry]
+	// seq is the sequence number that will be given to the next element.
+	seq uint64
+	// index, if not nil, maps every value to the positions of its
+	// occurrences, keyed by their sequence number.
+	index map[int]map[uint64]int
+}
+
+// Item is an element of the MinPQ: a value together with the priority that
+// determines its order.
+type Item struct {
+	Value    int
+	Priority int
+}
+
+func (it Item) String() string {
+	if it.Value == it.Priority {
+		return fmt.Sprintf("%d", it.Value)
+	}
+	return fmt.Sprintf("%d:%d", it.Value, it.Priority)
+}
+
+// entry is an Item tagged with the order in which it entered the PQ.
+type entry struct {
+	Item
+	seq uint64
 }
 
 // New returns a new MinPQ instance.
-func New() MinPQ {
-	return MinPQ{data: make([]int, 0)}
+func New(opts ...Option) MinPQ {
+	return newMinPQ(newPriorityQueue(entryLess(opts), newConfig(opts).arity), 0, opts)
+}
+
+// NewFromSlice returns a new MinPQ instance that contains a copy of the
+// specified `values`.
+//
+// Complexity: O(n)
+func NewFromSlice(values []int, opts ...Option) MinPQ {
+	data := make([]entry, len(values))
+	for i, v := range values {
+		data[i] = entry{Item: Item{Value: v, Priority: v}, seq: uint64(i)}
+	}
+	pq := newMinPQ(newPriorityQueue(entryLess(opts), newConfig(opts).arity), uint64(len(values)), opts)
+	pq.heap.data = data
+	pq.heap.heapify()
+	return pq
+}
+
+func newMinPQ(heap PriorityQueue[entry], seq uint64, opts []Option) MinPQ {
+	pq := MinPQ{heap: heap, seq: seq}
+	if newConfig(opts).valueIndex {
+		index := make(map[int]map[uint64]int)
+		pq.heap.moved = func(e entry, i int) {
+			positions, ok := index[e.Value]
+			if !ok {
+				positions = make(map[uint64]int)
+				index[e.Value] = positions
+			}
+			positions[e.seq] = i
+		}
+		pq.index = index
+	}
+	return pq
 }
 
 // Size returns the number of elements that are into the PQ.
 //
 // Complexity: O(1)
 func (pq *MinPQ) Size() int {
-	return len(pq.data)
+	return pq.heap.Size()
 }
 
 // IsEmpty returns whether the PQ is empty or not.
@@ -30,12 +92,51 @@ func (pq *MinPQ) IsEmpty() bool {
 	return pq.Size() == 0
 }
 
-// Add adds a new element with the specified `value` to the PQ.
+// Add adds a new element with the specified `value` to the PQ, using the
+// value itself as priority.
 //
 // Complexity: O(log(n))
 func (pq *MinPQ) Add(value int) {
-	pq.data = append(pq.data, value)
-	pq.bubbleUp(pq.Size() - 1)
+	pq.AddWithPriority(value, value)
+}
+
+// AddWithPriority adds a new element with the specified `value` to the PQ,
+// ordered by `priority`.
+//
+// Complexity: O(log(n))
+func (pq *MinPQ) AddWithPriority(value, priority int) {
+	pq.lazyInit()
+	pq.heap.Add(pq.newEntry(value, priority))
+}
+
+// AddAll adds all the specified `values` to the PQ, rebuilding the heap when
+// that is cheaper than adding them one at a time.
+//
+// Complexity: O(min(k*log(n+k), n+k))
+func (pq *MinPQ) AddAll(values ...int) {
+	pq.lazyInit()
+	entries := make([]entry, len(values))
+	for i, v := range values {
+		entries[i] = pq.newEntry(v, v)
+	}
+	pq.heap.AddAll(entries...)
+}
+
+// Meld moves all the elements of `other` into the PQ, leaving `other` empty.
+// The elements of `other` are considered as added after the ones of the PQ,
+// keeping their relative order.
+//
+// Complexity: O(min(k*log(n+k), n+k))
+func (pq *MinPQ) Meld(other *MinPQ) {
+	pq.lazyInit()
+	for i := range other.heap.data {
+		other.heap.data[i].seq += pq.seq
+	}
+	pq.seq += other.seq
+	other.seq = 0
+	// The positions of `other` are recorded again by the PQ while melding.
+	clear(other.index)
+	pq.heap.Meld(&other.heap)
 }
 
 // RemoveMin removes an element from the PQ, following the priority order, and
@@ -46,14 +147,21 @@ func (pq *MinPQ) RemoveMin() int {
 	if pq.IsEmpty() {
 		panic("RemoveMin: cannot remove from an empty PQ")
 	}
-	res := pq.data[0]
-	pq.data[0] = pq.data[pq.Size()-1]
-	pq.data = pq.data[:pq.Size()-1]
-	pq.bubbleDown(0)
-	return res
+	return pq.removeAt(0).Value
+}
+
+// RemoveMinItem removes an element from the PQ, following the priority
+// order, and returns both its value and its priority.
+//
+// Complexity: O(log(n))
+func (pq *MinPQ) RemoveMinItem() Item {
+	if pq.IsEmpty() {
+		panic("RemoveMinItem: cannot remove from an empty PQ")
+	}
+	return pq.removeAt(0).Item
 }
 
-// Peek returns the value of the next element the would be returned by
+// Peek returns the value of the next element that would be returned by
 // RemoveMin.
 //
 // Complexity: O(1)
@@ -61,98 +169,110 @@ func (pq *MinPQ) Peek() int {
 	if pq.IsEmpty() {
 		panic("Peek: cannot peek from an empty PQ")
 	}
-	return pq.data[0]
+	return pq.heap.Peek().Value
 }
 
-// Contains returns whether the PQ contains the specified `value` or not.
+// PeekItem returns the value and the priority of the next element that would
+// be returned by RemoveMin.
 //
-// Complexity: O(n)
-func (pq *MinPQ) Contains(value int) bool {
+// Complexity: O(1)
+func (pq *MinPQ) PeekItem() Item {
 	if pq.IsEmpty() {
-		return false
-	}
-	for _, v := range pq.data {
-		if v == value {
-			return true
-		}
+		panic("PeekItem: cannot peek from an empty PQ")
 	}
-	return false
+	return pq.heap.Peek().Item
 }
 
-// RemoveFirstOccurrence removes the first occurrence of the specified `value`.
+// Contains returns whether the PQ contains the specified `value` or not,
+// regardless of its priority.
 //
-// Complexity: O(n)
-func (pq *MinPQ) RemoveFirstOccurrence(value int) error {
-	if !pq.Contains(value) {
-		return fmt.Errorf("cannot remove value %d not in PQ", value)
+// Complexity: O(n), O(1) with a value index
+func (pq *MinPQ) Contains(value int) bool {
+	if pq.index != nil {
+		return len(pq.index[value]) > 0
 	}
+	return pq.heap.ContainsFunc(func(e entry) bool { return e.Value == value })
+}
 
-	// Find the index of the element to remove.
-	var i int
-	for i = range pq.data {
-		if pq.data[i] == value {
+// RemoveFirstOccurrence removes the first occurrence of the specified `value`,
+// in heap order, regardless of its priority. With a value index, any
+// occurrence of the value can be removed instead.
+//
+// Complexity: O(n), O(log(n)) with a value index
+func (pq *MinPQ) RemoveFirstOccurrence(value int) error {
+	i := -1
+	if pq.index != nil {
+		// The order of the heap is arbitrary anyway, so just take the first
+		// occurrence that the index yields.
+		for _, pos := range pq.index[value] {
+			i = pos
 			break
 		}
+	} else {
+		i = pq.heap.indexFunc(func(e entry) bool { return e.Value == value })
 	}
-
-	// Swap the element with the last.
-	last := pq.data[pq.Size()-1]
-	pq.data[i] = last
-	pq.data = pq.data[:pq.Size()-1]
-
-	// If the removed element was the last element there is no need to bubble.
-	if i == pq.Size() {
-		return nil
-	}
-
-	// Bubble down first, then, if nothing happened, try to bubble up.
-	pq.bubbleDown(i)
-	if pq.data[i] == last {
-		pq.bubbleUp(i)
+	if i < 0 {
+		return fmt.Errorf("cannot remove value %d not in PQ", value)
 	}
+	pq.removeAt(i)
 	return nil
 }
 
-func (pq *MinPQ) bubbleUp(fromIndex int) {
-	// Loop until a parent exists and it has a greater priority.
-	for fromIndex > 0 && pq.data[fromIndex] < pq.data[(fromIndex-1)/2] {
-		pq.swap(fromIndex, (fromIndex-1)/2)
-		fromIndex = (fromIndex - 1) / 2
+func (pq MinPQ) String() string {
+	res := "[ "
+	for _, e := range pq.heap.data {
+		res += e.Item.String()
+		res += " "
 	}
+	return res + "]"
 }
 
-func (pq *MinPQ) bubbleDown(fromIndex int) {
-	// Loop until a left child exists.
-	for (2*fromIndex)+1 < pq.Size() {
-		// Find which child, left or right, has the least priority.
-		minChildIndex := (2 * fromIndex) + 1
-		if (2*fromIndex)+2 < pq.Size() && pq.data[(2*fromIndex)+2] < pq.data[minChildIndex] {
-			minChildIndex = (2 * fromIndex) + 2
-		}
+// lazyInit sets up the heap of a zero value MinPQ, the first time that an
+// element is added.
+func (pq *MinPQ) lazyInit() {
+	if pq.heap.less == nil {
+		pq.heap = newPriorityQueue(entryLess(nil), defaultArity)
+	}
+}
 
-		// Bubble down if the child has a smaller priority.
-		if pq.data[minChildIndex] < pq.data[fromIndex] {
-			pq.swap(minChildIndex, fromIndex)
-		} else {
-			// Otherwise we are done.
-			return
-		}
+// removeAt removes the element at index `i`, also from the value index.
+func (pq *MinPQ) removeAt(i int) entry {
+	e := pq.heap.removeAt(i)
+	pq.unindex(e)
+	return e
+}
 
-		fromIndex = minChildIndex
+// unindex forgets the position of an element that has left the heap.
+func (pq *MinPQ) unindex(e entry) {
+	if pq.index == nil {
+		return
+	}
+	positions := pq.index[e.Value]
+	delete(positions, e.seq)
+	if len(positions) == 0 {
+		delete(pq.index, e.Value)
 	}
 }
 
-func (pq *MinPQ) swap(idx1, idx2 int) {
-	temp := pq.data[idx1]
-	pq.data[idx1] = pq.data[idx2]
-	pq.data[idx2] = temp
+func (pq *MinPQ) newEntry(value, priority int) entry {
+	e := entry{Item: Item{Value: value, Priority: priority}, seq: pq.seq}
+	pq.seq++
+	return e
 }
 
-func (pq MinPQ) String() string {
-	res := "[ "
-	for _, v := range pq.data {
-		res += fmt.Sprintf("%d", v)
-		res += " "
+// entryLess returns the ordering of the entries of a MinPQ built with
+// `opts`: by priority only, or by priority and then by insertion order if
+// the PQ is stable.
+func entryLess(opts []Option) func(a, b entry) bool {
+	if !newConfig(opts).stable {
+		return func(a, b entry) bool {
+			return a.Priority < b.Priority
+		}
+	}
+	return func(a, b entry) bool {
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.seq < b.seq
 	}
-	return res + "]"
 }
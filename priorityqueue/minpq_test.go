@@ -1,6 +1,10 @@
 package priorityqueue
 
-import "testing"
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
 
 func TestAdd(t *testing.T) {
 	pq := New()
@@ -32,6 +36,27 @@ func TestRemoveMin(t *testing.T) {
 	}
 }
 
+func TestZeroValue(t *testing.T) {
+	var pq MinPQ
+
+	pq.Add(3)
+	pq.Add(1)
+	pq.AddWithPriority(7, 2)
+
+	for _, want := range []int{1, 7, 3} {
+		if v := pq.RemoveMin(); v != want {
+			t.Errorf("wrong data: got %d want %d", v, want)
+		}
+	}
+
+	var other MinPQ
+	other.Meld(&MinPQ{})
+	other.AddAll(5, 4)
+	if v := other.Peek(); v != 4 {
+		t.Errorf("wrong data: got %d want %d", v, 4)
+	}
+}
+
 func TestPeek(t *testing.T) {
 	pq := New()
 
@@ -99,3 +124,204 @@ func TestRemoveFirstOccurrence(t *testing.T) {
 		}
 	}
 }
+
+func TestNewFromSlice(t *testing.T) {
+	values := []int{9, 4, 7, 1, 8, 2, 6, 3, 5}
+	pq := NewFromSlice(values)
+
+	if values[0] != 9 {
+		t.Errorf("the input slice should not be modified")
+	}
+	if pq.Size() != len(values) {
+		t.Errorf("the PQ should have a size of %d, but it does not", len(values))
+	}
+	for i := 1; !pq.IsEmpty(); i++ {
+		if v := pq.RemoveMin(); v != i {
+			t.Errorf("wrong data: got %d want %d", v, i)
+		}
+	}
+}
+
+func TestAddAll(t *testing.T) {
+	pq := New()
+
+	pq.Add(5)
+	pq.AddAll(3)
+	pq.AddAll(10, 1, 8, 2, 6, 9, 4, 7)
+
+	for i := 1; !pq.IsEmpty(); i++ {
+		if v := pq.RemoveMin(); v != i {
+			t.Errorf("wrong data: got %d want %d", v, i)
+		}
+	}
+}
+
+func TestMeld(t *testing.T) {
+	pq := NewFromSlice([]int{1, 3, 5, 7})
+	other := NewFromSlice([]int{2, 4, 6, 8})
+
+	pq.Meld(&other)
+
+	if !other.IsEmpty() {
+		t.Errorf("the melded PQ should be empty, but it is not")
+	}
+	for i := 1; !pq.IsEmpty(); i++ {
+		if v := pq.RemoveMin(); v != i {
+			t.Errorf("wrong data: got %d want %d", v, i)
+		}
+	}
+}
+
+func BenchmarkArityPushHeavy(b *testing.B) {
+	for _, d := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("d=%d", d), func(b *testing.B) {
+			r := rand.New(rand.NewSource(1))
+			pq := New(WithArity(d))
+			for i := 0; i < b.N; i++ {
+				// Four additions for every removal.
+				pq.Add(r.Int())
+				if i%4 == 3 {
+					pq.RemoveMin()
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkArityPopHeavy(b *testing.B) {
+	for _, d := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("d=%d", d), func(b *testing.B) {
+			r := rand.New(rand.NewSource(1))
+			values := make([]int, b.N)
+			for i := range values {
+				values[i] = r.Intn(1 << 40)
+			}
+			pq := NewFromSlice(values, WithArity(d))
+			b.ResetTimer()
+			// Only removals: the PQ is drained from its full size.
+			for i := 0; i < b.N; i++ {
+				pq.RemoveMin()
+			}
+		})
+	}
+}
+
+func TestAddWithPriority(t *testing.T) {
+	pq := New()
+
+	pq.AddWithPriority(100, 3)
+	pq.AddWithPriority(200, 1)
+	pq.AddWithPriority(300, 2)
+	pq.Add(0)
+
+	if it := pq.PeekItem(); it.Value != 0 || it.Priority != 0 {
+		t.Errorf("wrong item: got %v want %v", it, Item{0, 0})
+	}
+	if !pq.Contains(300) {
+		t.Errorf("the PQ must contains `%d`, but was not found", 300)
+	}
+	if pq.Contains(2) {
+		t.Errorf("the PQ does not contains `%d`, but was found", 2)
+	}
+	if err := pq.RemoveFirstOccurrence(0); err != nil {
+		t.Errorf("error while removing valid value %d", 0)
+	}
+
+	want := []Item{{200, 1}, {300, 2}, {100, 3}}
+	for _, w := range want {
+		if it := pq.RemoveMinItem(); it != w {
+			t.Errorf("wrong item: got %v want %v", it, w)
+		}
+	}
+}
+
+func TestStable(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	pq := New(Stable(), WithArity(3))
+
+	// The value is the insertion order, the priority is one of few levels.
+	next := 0
+	last := make(map[int]int)
+	for round := 0; round < 100; round++ {
+		for i := r.Intn(20); i > 0; i-- {
+			pq.AddWithPriority(next, r.Intn(4))
+			next++
+		}
+		for i := r.Intn(15); i > 0 && !pq.IsEmpty(); i-- {
+			it := pq.RemoveMinItem()
+			if prev, ok := last[it.Priority]; ok && it.Value < prev {
+				t.Fatalf("priority %d: got %d after %d", it.Priority, it.Value, prev)
+			}
+			last[it.Priority] = it.Value
+		}
+	}
+
+	a := New(Stable())
+	b := New(Stable())
+	for i := 0; i < 3; i++ {
+		b.AddWithPriority(10+i, 0)
+	}
+	a.AddWithPriority(0, 0)
+	a.AddWithPriority(1, 0)
+	a.Meld(&b)
+	for _, want := range []int{0, 1, 10, 11, 12} {
+		if v := a.RemoveMin(); v != want {
+			t.Errorf("wrong data after meld: got %d want %d", v, want)
+		}
+	}
+}
+
+func TestValueIndex(t *testing.T) {
+	r := rand.New(rand.NewSource(9))
+	indexed := New(WithValueIndex(), WithArity(4))
+	plain := New(WithArity(4))
+
+	for step := 0; step < 5000; step++ {
+		v := r.Intn(50)
+		switch op := r.Intn(4); op {
+		case 0, 1:
+			// The index may remove a different occurrence than a scan, so all
+			// the occurrences of a value share the same priority.
+			p := (v * 37) % 100
+			indexed.AddWithPriority(v, p)
+			plain.AddWithPriority(v, p)
+		case 2:
+			errI, errP := indexed.RemoveFirstOccurrence(v), plain.RemoveFirstOccurrence(v)
+			if (errI == nil) != (errP == nil) {
+				t.Fatalf("step %d: wrong RemoveFirstOccurrence(%d): got %v want %v", step, v, errI, errP)
+			}
+		default:
+			if !plain.IsEmpty() {
+				if got, want := indexed.RemoveMinItem().Priority, plain.RemoveMinItem().Priority; got != want {
+					t.Fatalf("step %d: wrong priority: got %d want %d", step, got, want)
+				}
+			}
+		}
+		if got, want := indexed.Contains(v), plain.Contains(v); got != want {
+			t.Fatalf("step %d: wrong Contains(%d): got %t want %t", step, v, got, want)
+		}
+	}
+
+	// Every position in the index must point to an occurrence of its value.
+	count := 0
+	for v, positions := range indexed.index {
+		for seq, i := range positions {
+			if e := indexed.heap.data[i]; e.Value != v || e.seq != seq {
+				t.Errorf("index out of sync at %d: got %v want value %d", i, e.Item, v)
+			}
+			count++
+		}
+	}
+	if count != indexed.Size() {
+		t.Errorf("wrong number of indexed positions: got %d want %d", count, indexed.Size())
+	}
+
+	other := NewFromSlice([]int{7, 7, 3}, WithValueIndex())
+	indexed.Meld(&other)
+	if other.Contains(7) {
+		t.Errorf("the melded PQ does not contains `%d`, but was found", 7)
+	}
+	if err := indexed.RemoveFirstOccurrence(3); err != nil || !indexed.Contains(7) {
+		t.Errorf("the PQ must contains the melded values")
+	}
+}
@@ -0,0 +1,68 @@
+package priorityqueue
+
+// Option configures a priority queue at construction time.
+//
+// WithArity applies to every priority queue built on a heap, while Stable
+// and WithValueIndex only apply to a MinPQ: the other constructors panic
+// when given them.
+type Option func(*config)
+
+type config struct {
+	arity      int
+	stable     bool
+	valueIndex bool
+}
+
+const (
+	defaultArity = 2
+)
+
+func newConfig(opts []Option) config {
+	cfg := config{arity: defaultArity}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithArity sets the number of children `d` of every node of the underlying
+// heap. Wider nodes make the heap shallower, so adding elements and
+// decreasing priorities get cheaper, while removals compare more children at
+// every level.
+func WithArity(d int) Option {
+	if d < 2 {
+		panic("arity must be at least 2")
+	}
+	return func(cfg *config) {
+		cfg.arity = d
+	}
+}
+
+// Stable makes a MinPQ return the elements with the same priority in the
+// order in which they have been added, by tagging every element with a
+// sequence number used to break ties.
+func Stable() Option {
+	return func(cfg *config) {
+		cfg.stable = true
+	}
+}
+
+// WithValueIndex makes a MinPQ keep a map from every value to the positions
+// of its occurrences, updated on every swap, so that Contains runs in O(1)
+// and RemoveFirstOccurrence in O(log(n)), at the cost of extra memory and
+// slower updates.
+func WithValueIndex() Option {
+	return func(cfg *config) {
+		cfg.valueIndex = true
+	}
+}
+
+// heapConfig returns the configuration for a priority queue other than a
+// MinPQ, rejecting the options that only apply to a MinPQ.
+func heapConfig(opts []Option) config {
+	cfg := newConfig(opts)
+	if cfg.stable || cfg.valueIndex {
+		panic("Stable and WithValueIndex can only be used with a MinPQ")
+	}
+	return cfg
+}
@@ -0,0 +1,301 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"fmt"
+	"math/bits"
+)
+
+// PriorityQueue is a generic priority queue implemented with an array-based
+// d-ary heap (binary by default, see WithArity). The order of extraction is
+// determined by the `less` function given at construction time: the element
+// for which `less` reports true against every other element is the first one
+// to be removed.
+//
+// Unlike MinPQ and MaxPQ, the zero value is not ready to use, as it has no
+// ordering: use NewPriorityQueue.
+type PriorityQueue[T any] struct {
+	data  []T
+	less  func(a, b T) bool
+	arity int
+
+	// moved, if not nil, is called every time an element is placed at a new
+	// index of the heap, so that wrappers can keep track of positions.
+	moved func(x T, i int)
+}
+
+// NewPriorityQueue returns a new PriorityQueue instance ordered by `less`.
+func NewPriorityQueue[T any](less func(a, b T) bool, opts ...Option) PriorityQueue[T] {
+	return newPriorityQueue(less, heapConfig(opts).arity)
+}
+
+func newPriorityQueue[T any](less func(a, b T) bool, arity int) PriorityQueue[T] {
+	if less == nil {
+		panic("less function must not be nil")
+	}
+	return PriorityQueue[T]{data: make([]T, 0), less: less, arity: arity}
+}
+
+// Heapify returns a new PriorityQueue instance ordered by `less` that
+// contains the elements of `data`.
+//
+// The PQ adopts `data` as its storage: the slice is rearranged in place and
+// must not be used by the caller afterwards.
+//
+// Complexity: O(n)
+func Heapify[T any](data []T, less func(a, b T) bool, opts ...Option) PriorityQueue[T] {
+	pq := NewPriorityQueue(less, opts...)
+	pq.data = data
+	pq.heapify()
+	return pq
+}
+
+// NewMinOrdered returns a new PriorityQueue instance that removes the least
+// element first, according to the natural ordering of `T`.
+func NewMinOrdered[T cmp.Ordered](opts ...Option) PriorityQueue[T] {
+	return NewPriorityQueue(cmp.Less[T], opts...)
+}
+
+// NewMaxOrdered returns a new PriorityQueue instance that removes the
+// greatest element first, according to the natural ordering of `T`.
+func NewMaxOrdered[T cmp.Ordered](opts ...Option) PriorityQueue[T] {
+	return NewPriorityQueue(func(a, b T) bool { return cmp.Less(b, a) }, opts...)
+}
+
+// Size returns the number of elements that are into the PQ.
+//
+// Complexity: O(1)
+func (pq *PriorityQueue[T]) Size() int {
+	return len(pq.data)
+}
+
+// IsEmpty returns whether the PQ is empty or not.
+//
+// Complexity: O(1)
+func (pq *PriorityQueue[T]) IsEmpty() bool {
+	return pq.Size() == 0
+}
+
+// Add adds a new element with the specified `value` to the PQ.
+//
+// Complexity: O(log(n))
+func (pq *PriorityQueue[T]) Add(value T) {
+	pq.pushLast(value)
+	pq.bubbleUp(pq.Size() - 1)
+}
+
+// AddAll adds all the specified `values` to the PQ. When the batch is big
+// enough with respect to the PQ, the whole heap is rebuilt from scratch
+// instead of bubbling up every new element.
+//
+// Complexity: O(min(k*log(n+k), n+k))
+func (pq *PriorityQueue[T]) AddAll(values ...T) {
+	total := pq.Size() + len(values)
+
+	// Rebuilding the heap costs about 2(n+k) comparisons, while adding the
+	// elements one at a time costs up to k*log(n+k).
+	if len(values)*bits.Len(uint(total)) <= 2*total {
+		for _, v := range values {
+			pq.Add(v)
+		}
+		return
+	}
+
+	pq.data = append(pq.data, values...)
+	pq.heapify()
+}
+
+// Meld moves all the elements of `other` into the PQ, leaving `other` empty.
+//
+// Complexity: O(min(k*log(n+k), n+k))
+func (pq *PriorityQueue[T]) Meld(other *PriorityQueue[T]) {
+	values := other.data
+	other.data = make([]T, 0)
+	pq.AddAll(values...)
+}
+
+// Remove removes an element from the PQ, following the priority order, and
+// returns its value.
+//
+// Complexity: O(log(n))
+func (pq *PriorityQueue[T]) Remove() T {
+	if pq.IsEmpty() {
+		panic("Remove: cannot remove from an empty PQ")
+	}
+	return pq.removeAt(0)
+}
+
+// Peek returns the value of the next element that would be returned by
+// Remove.
+//
+// Complexity: O(1)
+func (pq *PriorityQueue[T]) Peek() T {
+	if pq.IsEmpty() {
+		panic("Peek: cannot peek from an empty PQ")
+	}
+	return pq.data[0]
+}
+
+// ContainsFunc returns whether the PQ contains an element that satisfies
+// `match` or not.
+//
+// Complexity: O(n)
+func (pq *PriorityQueue[T]) ContainsFunc(match func(T) bool) bool {
+	return pq.indexFunc(match) >= 0
+}
+
+// RemoveFirstFunc removes the first element that satisfies `match`, in heap
+// order, and returns it.
+//
+// Complexity: O(n)
+func (pq *PriorityQueue[T]) RemoveFirstFunc(match func(T) bool) (T, error) {
+	i := pq.indexFunc(match)
+	if i < 0 {
+		var zero T
+		return zero, fmt.Errorf("cannot remove value not in PQ")
+	}
+	return pq.removeAt(i), nil
+}
+
+func (pq *PriorityQueue[T]) indexFunc(match func(T) bool) int {
+	for i, v := range pq.data {
+		if match(v) {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeAt removes the element at index `i` and restores the heap invariant.
+func (pq *PriorityQueue[T]) removeAt(i int) T {
+	last := pq.Size() - 1
+	res := pq.data[i]
+
+	// Swap the element with the last one, then drop it.
+	pq.swap(i, last)
+	pq.popLast()
+
+	// If the removed element was the last element there is no need to bubble.
+	if i == last {
+		return res
+	}
+
+	// Bubble down first, then, if nothing happened, try to bubble up.
+	pq.fix(i)
+	return res
+}
+
+// pushLast appends `value` at the end of the storage, without restoring the
+// heap invariant.
+func (pq *PriorityQueue[T]) pushLast(value T) {
+	pq.data = append(pq.data, value)
+	if pq.moved != nil {
+		pq.moved(value, pq.Size()-1)
+	}
+}
+
+// popLast removes the last element of the storage, without restoring the
+// heap invariant.
+func (pq *PriorityQueue[T]) popLast() T {
+	last := pq.Size() - 1
+	res := pq.data[last]
+	var zero T
+	pq.data[last] = zero
+	pq.data = pq.data[:last]
+	return res
+}
+
+// replaceTop replaces the first element with `value` and restores the heap
+// invariant, which is cheaper than a removal followed by an addition.
+func (pq *PriorityQueue[T]) replaceTop(value T) {
+	pq.data[0] = value
+	if pq.moved != nil {
+		pq.moved(value, 0)
+	}
+	pq.bubbleDown(0)
+}
+
+// heapify restores the heap invariant over the whole storage using Floyd's
+// bottom-up construction.
+func (pq *PriorityQueue[T]) heapify() {
+	if pq.moved != nil {
+		for i, v := range pq.data {
+			pq.moved(v, i)
+		}
+	}
+
+	// Leaves are already heaps, so start from the last internal node.
+	for i := pq.parent(pq.Size() - 1); i >= 0; i-- {
+		pq.bubbleDown(i)
+	}
+}
+
+// fix restores the heap invariant after the element at index `i` has changed
+// its priority.
+func (pq *PriorityQueue[T]) fix(i int) {
+	if !pq.bubbleDown(i) {
+		pq.bubbleUp(i)
+	}
+}
+
+func (pq *PriorityQueue[T]) bubbleUp(fromIndex int) {
+	// Loop until a parent exists and it has a greater priority.
+	for fromIndex > 0 && pq.less(pq.data[fromIndex], pq.data[pq.parent(fromIndex)]) {
+		pq.swap(fromIndex, pq.parent(fromIndex))
+		fromIndex = pq.parent(fromIndex)
+	}
+}
+
+// bubbleDown moves the element at `fromIndex` down the heap and reports
+// whether it has been moved or not.
+func (pq *PriorityQueue[T]) bubbleDown(fromIndex int) bool {
+	moved := false
+
+	// Loop until a first child exists.
+	for pq.firstChild(fromIndex) < pq.Size() {
+		// Find which child has the least priority.
+		minChildIndex := pq.firstChild(fromIndex)
+		last := min(minChildIndex+pq.arity, pq.Size())
+		for c := minChildIndex + 1; c < last; c++ {
+			if pq.less(pq.data[c], pq.data[minChildIndex]) {
+				minChildIndex = c
+			}
+		}
+
+		// Bubble down if the child has a smaller priority.
+		if !pq.less(pq.data[minChildIndex], pq.data[fromIndex]) {
+			// Otherwise we are done.
+			break
+		}
+		pq.swap(minChildIndex, fromIndex)
+		moved = true
+
+		fromIndex = minChildIndex
+	}
+	return moved
+}
+
+func (pq *PriorityQueue[T]) parent(i int) int {
+	return (i - 1) / pq.arity
+}
+
+func (pq *PriorityQueue[T]) firstChild(i int) int {
+	return pq.arity*i + 1
+}
+
+func (pq *PriorityQueue[T]) swap(idx1, idx2 int) {
+	pq.data[idx1], pq.data[idx2] = pq.data[idx2], pq.data[idx1]
+	if pq.moved != nil {
+		pq.moved(pq.data[idx1], idx1)
+		pq.moved(pq.data[idx2], idx2)
+	}
+}
+
+func (pq PriorityQueue[T]) String() string {
+	res := "[ "
+	for _, v := range pq.data {
+		res += fmt.Sprintf("%v", v)
+		res += " "
+	}
+	return res + "]"
+}
@@ -0,0 +1,119 @@
+package priorityqueue
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+type job struct {
+	name     string
+	deadline int
+}
+
+func TestPriorityQueueRemove(t *testing.T) {
+	pq := NewPriorityQueue(func(a, b job) bool { return a.deadline < b.deadline })
+
+	pq.Add(job{"c", 30})
+	pq.Add(job{"a", 10})
+	pq.Add(job{"e", 50})
+	pq.Add(job{"b", 20})
+	pq.Add(job{"d", 40})
+
+	if s := pq.Size(); s != 5 {
+		t.Errorf("wrong size: got %d want %d", s, 5)
+	}
+	if j := pq.Peek(); j.name != "a" {
+		t.Errorf("wrong peek: got %q want %q", j.name, "a")
+	}
+	for _, want := range []string{"a", "b", "c", "d", "e"} {
+		if j := pq.Remove(); j.name != want {
+			t.Errorf("wrong data: got %q want %q", j.name, want)
+		}
+	}
+}
+
+func TestPriorityQueueMaxOrdered(t *testing.T) {
+	pq := NewMaxOrdered[float64]()
+
+	for _, v := range []float64{0.5, 2.5, 1.5, 3.5} {
+		pq.Add(v)
+	}
+
+	for _, want := range []float64{3.5, 2.5, 1.5, 0.5} {
+		if v := pq.Remove(); v != want {
+			t.Errorf("wrong data: got %v want %v", v, want)
+		}
+	}
+}
+
+func TestPriorityQueueRemoveFirstFunc(t *testing.T) {
+	pq := NewMinOrdered[int]()
+
+	if _, err := pq.RemoveFirstFunc(func(v int) bool { return v == 42 }); err == nil {
+		t.Errorf("the PQ is empty, cannot contains any value")
+	}
+
+	for i := 1; i <= 10; i++ {
+		pq.Add(i)
+	}
+
+	v, err := pq.RemoveFirstFunc(func(v int) bool { return v%5 == 0 })
+	if err != nil || v%5 != 0 {
+		t.Errorf("wrong removal: got %d (err %v)", v, err)
+	}
+	if pq.ContainsFunc(func(x int) bool { return x == v }) {
+		t.Errorf("the PQ does not contains `%d`, but was found", v)
+	}
+}
+
+func TestHeapify(t *testing.T) {
+	data := []int{5, 3, 8, 1, 9, 2}
+	pq := Heapify(data, func(a, b int) bool { return a < b })
+
+	if data[0] != 1 {
+		t.Errorf("the adopted slice should be rearranged in place: got %d want %d", data[0], 1)
+	}
+	for _, want := range []int{1, 2, 3, 5, 8, 9} {
+		if v := pq.Remove(); v != want {
+			t.Errorf("wrong data: got %d want %d", v, want)
+		}
+	}
+}
+
+func TestPriorityQueueArity(t *testing.T) {
+	for d := 2; d <= 8; d++ {
+		r := rand.New(rand.NewSource(int64(d)))
+		values := r.Perm(200)
+		pq := NewMinOrdered[int](WithArity(d))
+
+		for _, v := range values[:100] {
+			pq.Add(v)
+		}
+		pq.AddAll(values[100:]...)
+		for i := 0; i < 50; i++ {
+			pq.RemoveFirstFunc(func(x int) bool { return x == values[i] })
+		}
+
+		want := append([]int(nil), values[50:]...)
+		sort.Ints(want)
+		for _, w := range want {
+			if v := pq.Remove(); v != w {
+				t.Errorf("arity %d: wrong data: got %d want %d", d, v, w)
+			}
+		}
+	}
+}
+
+func TestMinPQOnlyOptions(t *testing.T) {
+	for _, opt := range []Option{Stable(), WithValueIndex()} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("an option that only applies to a MinPQ did not panic")
+				}
+			}()
+			NewMinOrdered[int](opt)
+		}()
+	}
+}
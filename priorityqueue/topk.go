@@ -0,0 +1,99 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"slices"
+)
+
+// TopK collects the best `k` elements out of a stream of values, using a
+// bounded heap whose root is the worst element kept so far.
+//
+// An element is better than another if it comes after it according to the
+// `less` function, so with the natural ordering TopK keeps the greatest
+// elements.
+//
+// The zero value is not ready to use, as it has neither ordering nor `k`:
+// use NewTopK or NewTopKFunc.
+type TopK[T any] struct {
+	heap PriorityQueue[T]
+	k    int
+}
+
+// NewTopK returns a new TopK instance that keeps the `k` greatest elements,
+// according to the natural ordering of `T`.
+func NewTopK[T cmp.Ordered](k int) TopK[T] {
+	return NewTopKFunc(k, cmp.Less[T])
+}
+
+// NewTopKFunc returns a new TopK instance that keeps the `k` greatest
+// elements according to `less`.
+func NewTopKFunc[T any](k int, less func(a, b T) bool) TopK[T] {
+	if k <= 0 {
+		panic("k must be a positive number")
+	}
+	heap := NewPriorityQueue(less)
+	heap.data = make([]T, 0, k)
+	return TopK[T]{heap: heap, k: k}
+}
+
+// Size returns the number of elements that are currently kept, which is at
+// most K.
+//
+// Complexity: O(1)
+func (tk *TopK[T]) Size() int {
+	return tk.heap.Size()
+}
+
+// K returns the maximum number of elements that are kept.
+//
+// Complexity: O(1)
+func (tk *TopK[T]) K() int {
+	return tk.k
+}
+
+// Peek returns the worst element among the ones that are kept, that is the
+// element a new value has to beat once the collector is full.
+//
+// Complexity: O(1)
+func (tk *TopK[T]) Peek() T {
+	if tk.heap.IsEmpty() {
+		panic("Peek: cannot peek from an empty TopK")
+	}
+	return tk.heap.Peek()
+}
+
+// Offer proposes the specified `value` to the collector, and returns whether
+// it has been kept or not. Once K elements are kept, a better value evicts
+// the worst one.
+//
+// Complexity: O(log(k))
+func (tk *TopK[T]) Offer(value T) bool {
+	if tk.Size() < tk.k {
+		tk.heap.Add(value)
+		return true
+	}
+	if !tk.heap.less(tk.heap.Peek(), value) {
+		return false
+	}
+	tk.heap.replaceTop(value)
+	return true
+}
+
+// Sorted returns the elements that are kept, from the best to the worst,
+// without modifying the collector.
+//
+// Complexity: O(k*log(k))
+func (tk *TopK[T]) Sorted() []T {
+	res := slices.Clone(tk.heap.data)
+	slices.SortFunc(res, func(a, b T) int {
+		switch {
+		case tk.heap.less(b, a):
+			return -1
+		case tk.heap.less(a, b):
+			return 1
+		default:
+			return 0
+		}
+	})
+	return res
+}
@@ -0,0 +1,71 @@
+package priorityqueue
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestTopKOffer(t *testing.T) {
+	tk := NewTopK[int](3)
+
+	for _, v := range []int{5, 1, 4} {
+		if !tk.Offer(v) {
+			t.Errorf("offer of %d should have been accepted while not full", v)
+		}
+	}
+	if tk.Offer(0) {
+		t.Errorf("offer of %d should have been rejected", 0)
+	}
+	if !tk.Offer(7) {
+		t.Errorf("offer of %d should have been accepted", 7)
+	}
+	if v := tk.Peek(); v != 4 {
+		t.Errorf("wrong worst element: got %d want %d", v, 4)
+	}
+	if s := tk.Size(); s != 3 {
+		t.Errorf("wrong size: got %d want %d", s, 3)
+	}
+}
+
+func TestTopKSorted(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+	for _, k := range []int{1, 10, 100, 5000} {
+		tk := NewTopK[int](k)
+		var all []int
+		for i := 0; i < 2000; i++ {
+			v := r.Intn(1000)
+			all = append(all, v)
+			tk.Offer(v)
+		}
+		if tk.Size() > k {
+			t.Errorf("k=%d: the collector should keep at most %d elements, got %d", k, k, tk.Size())
+		}
+
+		sort.Sort(sort.Reverse(sort.IntSlice(all)))
+		want := all[:min(k, len(all))]
+		got := tk.Sorted()
+		if len(got) != len(want) {
+			t.Fatalf("k=%d: wrong length: got %d want %d", k, len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("k=%d: wrong data at %d: got %d want %d", k, i, got[i], want[i])
+			}
+		}
+	}
+}
+
+func TestTopKFunc(t *testing.T) {
+	// Keep the jobs with the earliest deadlines.
+	tk := NewTopKFunc(2, func(a, b job) bool { return a.deadline > b.deadline })
+
+	for _, j := range []job{{"c", 30}, {"a", 10}, {"e", 50}, {"b", 20}} {
+		tk.Offer(j)
+	}
+
+	got := tk.Sorted()
+	if len(got) != 2 || got[0].name != "a" || got[1].name != "b" {
+		t.Errorf("wrong result: got %v", got)
+	}
+}
@@ -0,0 +1,197 @@
+package radixheap
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// Item is an element of the RadixHeap: a value together with the priority
+// that determines its order.
+type Item struct {
+	Value    int
+	Priority int
+}
+
+// RadixHeap is a monotone priority queue for non-negative integer
+// priorities: the priority of every added element must not be smaller than
+// the last removed one. This makes it suitable for algorithms like Dijkstra's
+// shortest paths.
+//
+// Elements are kept in buckets according to the highest bit in which their
+// priority differs from the last removed one, so that no comparison between
+// elements is needed when adding them.
+type RadixHeap struct {
+	buckets [bits.UintSize + 1][]Item
+	last    int
+	size    int
+
+	// topBucket and topIndex locate the next element to be removed, if
+	// topKnown.
+	topBucket int
+	topIndex  int
+	topKnown  bool
+}
+
+// New returns a new RadixHeap instance.
+func New() RadixHeap {
+	return RadixHeap{last: 0, size: 0}
+}
+
+// Size returns the number of elements that are into the heap.
+//
+// Complexity: O(1)
+func (h *RadixHeap) Size() int {
+	return h.size
+}
+
+// IsEmpty returns whether the heap is empty or not.
+//
+// Complexity: O(1)
+func (h *RadixHeap) IsEmpty() bool {
+	return h.Size() == 0
+}
+
+// Add adds a new element with the specified `key` to the heap, using the key
+// itself as priority.
+//
+// Complexity: O(1)
+func (h *RadixHeap) Add(key int) error {
+	return h.AddWithPriority(key, key)
+}
+
+// AddWithPriority adds a new element with the specified `value` to the heap,
+// ordered by `priority`. It returns an error if the priority is negative or
+// smaller than the one of the last removed element.
+//
+// Complexity: O(1)
+func (h *RadixHeap) AddWithPriority(value, priority int) error {
+	if priority < 0 {
+		return fmt.Errorf("cannot add negative priority %d", priority)
+	}
+	if priority < h.last {
+		return fmt.Errorf("cannot add priority %d smaller than the last removed %d", priority, h.last)
+	}
+	b := h.bucket(priority)
+	h.buckets[b] = append(h.buckets[b], Item{Value: value, Priority: priority})
+	h.size++
+
+	// Among equal priorities, the one added last is removed first.
+	if h.topKnown && priority <= h.buckets[h.topBucket][h.topIndex].Priority {
+		h.topBucket, h.topIndex = b, len(h.buckets[b])-1
+	}
+	return nil
+}
+
+// Peek returns the value of the next element that would be returned by
+// RemoveMin.
+//
+// Complexity: O(log(C)) amortized, where C is the largest priority
+func (h *RadixHeap) Peek() int {
+	return h.PeekItem().Value
+}
+
+// PeekItem returns the value and the priority of the next element that would
+// be returned by RemoveMin.
+//
+// Complexity: O(log(C)) amortized, where C is the largest priority
+func (h *RadixHeap) PeekItem() Item {
+	if h.IsEmpty() {
+		panic("PeekItem: cannot peek from an empty heap")
+	}
+	if !h.topKnown {
+		b := h.firstBucket()
+		i := len(h.buckets[b]) - 1
+		if b > 0 {
+			i = lastMinIndex(h.buckets[b])
+		}
+		h.topBucket, h.topIndex = b, i
+		h.topKnown = true
+	}
+	return h.buckets[h.topBucket][h.topIndex]
+}
+
+// RemoveMin removes the element with the least priority from the heap and
+// returns its value.
+//
+// Complexity: O(log(C)) amortized, where C is the largest priority
+func (h *RadixHeap) RemoveMin() int {
+	if h.IsEmpty() {
+		panic("RemoveMin: cannot remove from an empty heap")
+	}
+	return h.RemoveMinItem().Value
+}
+
+// RemoveMinItem removes the element with the least priority from the heap
+// and returns both its value and its priority.
+//
+// Complexity: O(log(C)) amortized, where C is the largest priority
+func (h *RadixHeap) RemoveMinItem() Item {
+	if h.IsEmpty() {
+		panic("RemoveMinItem: cannot remove from an empty heap")
+	}
+
+	// When the first bucket is empty, the minimum becomes the new reference
+	// and the first non-empty bucket is split among the lower ones. Every
+	// element moves to a strictly lower bucket, which bounds the total work.
+	// The elements with the least priority end up in the first bucket, in
+	// the same order, so the last one is the same that Peek returns.
+	if len(h.buckets[0]) == 0 {
+		b := h.firstBucket()
+		items := h.buckets[b]
+		h.buckets[b] = items[:0:0]
+		h.last = items[lastMinIndex(items)].Priority
+		for _, it := range items {
+			nb := h.bucket(it.Priority)
+			h.buckets[nb] = append(h.buckets[nb], it)
+		}
+	}
+
+	last := len(h.buckets[0]) - 1
+	res := h.buckets[0][last]
+	h.buckets[0] = h.buckets[0][:last]
+	h.size--
+	h.topKnown = false
+	return res
+}
+
+// bucket returns the bucket for `priority`, that is the position of the
+// highest bit in which it differs from the last removed priority.
+func (h *RadixHeap) bucket(priority int) int {
+	return bits.Len(uint(priority ^ h.last))
+}
+
+func (h *RadixHeap) firstBucket() int {
+	for b := range h.buckets {
+		if len(h.buckets[b]) > 0 {
+			return b
+		}
+	}
+	panic("cannot find a non-empty bucket")
+}
+
+// lastMinIndex returns the index of the last element with the least
+// priority.
+func lastMinIndex(items []Item) int {
+	res := 0
+	for i, it := range items {
+		if it.Priority <= items[res].Priority {
+			res = i
+		}
+	}
+	return res
+}
+
+func (h RadixHeap) String() string {
+	res := "[ "
+	for b, items := range h.buckets {
+		if len(items) == 0 {
+			continue
+		}
+		res += fmt.Sprintf("%d:{ ", b)
+		for _, it := range items {
+			res += fmt.Sprintf("%d ", it.Priority)
+		}
+		res += "} "
+	}
+	return res + "]"
+}
@@ -0,0 +1,207 @@
+package radixheap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/BuriedInTheGround/datastructures/priorityqueue"
+)
+
+func TestRemoveMin(t *testing.T) {
+	h := New()
+
+	for _, v := range []int{2, 5, 4, 1, 3} {
+		if err := h.Add(v); err != nil {
+			t.Errorf("error while adding valid key %d: %v", v, err)
+		}
+	}
+
+	if v := h.Peek(); v != 1 {
+		t.Errorf("wrong data: got %d want %d", v, 1)
+	}
+	for i := 1; !h.IsEmpty(); i++ {
+		if v := h.RemoveMin(); v != i {
+			t.Errorf("wrong data: got %d want %d", v, i)
+		}
+	}
+}
+
+func TestMonotonicity(t *testing.T) {
+	h := New()
+
+	if err := h.Add(-1); err == nil {
+		t.Errorf("add of a negative key should have returned an error")
+	}
+
+	h.Add(10)
+	h.Add(20)
+	h.RemoveMin()
+
+	if err := h.Add(9); err == nil {
+		t.Errorf("add of a key smaller than the last removed should have returned an error")
+	}
+	if err := h.Add(10); err != nil {
+		t.Errorf("add of a key equal to the last removed should have succeeded: %v", err)
+	}
+	if err := h.AddWithPriority(42, 15); err != nil {
+		t.Errorf("error while adding valid priority %d: %v", 15, err)
+	}
+
+	want := []Item{{10, 10}, {42, 15}, {20, 20}}
+	for _, w := range want {
+		if it := h.RemoveMinItem(); it != w {
+			t.Errorf("wrong item: got %v want %v", it, w)
+		}
+	}
+}
+
+func TestPeekDuplicates(t *testing.T) {
+	h := New()
+
+	h.AddWithPriority(1, 5)
+	h.AddWithPriority(2, 5)
+	h.AddWithPriority(3, 5)
+	h.AddWithPriority(4, 9)
+
+	for !h.IsEmpty() {
+		p := h.Peek()
+		if v := h.RemoveMin(); v != p {
+			t.Errorf("wrong data: got %d want %d", v, p)
+		}
+	}
+}
+
+func TestAddAfterPeek(t *testing.T) {
+	h := New()
+
+	h.Add(5)
+	h.RemoveMin()
+	h.Add(10)
+	if v := h.Peek(); v != 10 {
+		t.Errorf("wrong data: got %d want %d", v, 10)
+	}
+
+	// Peeking must not raise the bound set by the last removed element.
+	if err := h.Add(7); err != nil {
+		t.Errorf("add of a key bigger than the last removed should have succeeded: %v", err)
+	}
+	h.AddWithPriority(42, 7)
+	for _, want := range []int{42, 7, 10} {
+		if p := h.Peek(); p != want {
+			t.Errorf("wrong peek: got %d want %d", p, want)
+		}
+		if v := h.RemoveMin(); v != want {
+			t.Errorf("wrong data: got %d want %d", v, want)
+		}
+	}
+}
+
+func TestRandomizedMonotone(t *testing.T) {
+	r := rand.New(rand.NewSource(19))
+	h := New()
+	var pending []int
+	last := 0
+
+	for step := 0; step < 10000; step++ {
+		if r.Intn(3) > 0 || len(pending) == 0 {
+			k := last + r.Intn(1000)
+			h.Add(k)
+			pending = append(pending, k)
+			if r.Intn(2) == 0 {
+				h.Peek()
+			}
+			continue
+		}
+		sort.Ints(pending)
+		if v := h.Peek(); v != pending[0] {
+			t.Fatalf("step %d: wrong peek: got %d want %d", step, v, pending[0])
+		}
+		if v := h.RemoveMin(); v != pending[0] {
+			t.Fatalf("step %d: wrong data: got %d want %d", step, v, pending[0])
+		}
+		last = pending[0]
+		pending = pending[1:]
+	}
+}
+
+// graph is a random directed graph with small integer weights.
+type graph [][]struct{ to, weight int }
+
+func randomGraph(n, degree, maxWeight int) graph {
+	r := rand.New(rand.NewSource(1))
+	g := make(graph, n)
+	for u := range g {
+		for i := 0; i < degree; i++ {
+			g[u] = append(g[u], struct{ to, weight int }{r.Intn(n), 1 + r.Intn(maxWeight)})
+		}
+	}
+	return g
+}
+
+// shortestPaths runs Dijkstra's algorithm from vertex 0, with lazy deletion
+// of the stale entries, on top of the given add and remove functions.
+func shortestPaths(g graph, add func(v, d int), remove func() (int, int), empty func() bool) []int {
+	dist := make([]int, len(g))
+	for i := range dist {
+		dist[i] = -1
+	}
+	add(0, 0)
+	for !empty() {
+		u, d := remove()
+		if dist[u] >= 0 {
+			continue
+		}
+		dist[u] = d
+		for _, e := range g[u] {
+			if dist[e.to] < 0 {
+				add(e.to, d+e.weight)
+			}
+		}
+	}
+	return dist
+}
+
+func TestShortestPaths(t *testing.T) {
+	g := randomGraph(1000, 4, 10)
+
+	h := New()
+	got := shortestPaths(g,
+		func(v, d int) { h.AddWithPriority(v, d) },
+		func() (int, int) { it := h.RemoveMinItem(); return it.Value, it.Priority },
+		h.IsEmpty)
+
+	pq := priorityqueue.New()
+	want := shortestPaths(g,
+		func(v, d int) { pq.AddWithPriority(v, d) },
+		func() (int, int) { it := pq.RemoveMinItem(); return it.Value, it.Priority },
+		pq.IsEmpty)
+
+	for v := range want {
+		if got[v] != want[v] {
+			t.Errorf("wrong distance of %d: got %d want %d", v, got[v], want[v])
+		}
+	}
+}
+
+func BenchmarkShortestPaths(b *testing.B) {
+	g := randomGraph(100000, 4, 16)
+	for i := 0; i < b.N; i++ {
+		h := New()
+		shortestPaths(g,
+			func(v, d int) { h.AddWithPriority(v, d) },
+			func() (int, int) { it := h.RemoveMinItem(); return it.Value, it.Priority },
+			h.IsEmpty)
+	}
+}
+
+func BenchmarkMinPQShortestPaths(b *testing.B) {
+	g := randomGraph(100000, 4, 16)
+	for i := 0; i < b.N; i++ {
+		pq := priorityqueue.New()
+		shortestPaths(g,
+			func(v, d int) { pq.AddWithPriority(v, d) },
+			func() (int, int) { it := pq.RemoveMinItem(); return it.Value, it.Priority },
+			pq.IsEmpty)
+	}
+}
@@ -0,0 +1,130 @@
+package unionfind
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// ConcurrentUnionFind is a UnionFind that can be used by many goroutines at
+// the same time without locks: every change to the parent of an element is
+// made with an atomic compare-and-swap.
+//
+// Roots are linked by index, the one with the lesser index becoming a child
+// of the other, so that the parent of an element always has a greater index
+// and no cycle can be formed, even by concurrent unions. Find applies path
+// halving.
+type ConcurrentUnionFind struct {
+	data       []atomic.Int64
+	components atomic.Int64
+}
+
+// NewConcurrent returns a new ConcurrentUnionFind instance with the specified
+// `size` as number of elements.
+//
+// Complexity: O(n)
+func NewConcurrent(size int) *ConcurrentUnionFind {
+	if size < 0 {
+		panic("size must not be a negative number")
+	}
+
+	uf := &ConcurrentUnionFind{data: make([]atomic.Int64, size)}
+	for i := range uf.data {
+		uf.data[i].Store(int64(i))
+	}
+	uf.components.Store(int64(size))
+	return uf
+}
+
+// Size returns the number of elements that are into the UnionFind.
+//
+// Complexity: O(1)
+func (uf *ConcurrentUnionFind) Size() int {
+	return len(uf.data)
+}
+
+// Components returns the number of components (or groups) that the UnionFind
+// has.
+//
+// Complexity: O(1)
+func (uf *ConcurrentUnionFind) Components() int {
+	return int(uf.components.Load())
+}
+
+// Find finds to which component/group the requested `element` belongs to and
+// returns its root, that was a root at some point during the call.
+// This method also applies Path Halving.
+//
+// Complexity: O(log(n)) amortized
+func (uf *ConcurrentUnionFind) Find(element int) int {
+	if element < 0 || element >= uf.Size() {
+		panic("cannot exists such element inside this UnionFind")
+	}
+	return int(uf.find(int64(element)))
+}
+
+// Connected returns whether two elements belongs to the same component or
+// not.
+//
+// Complexity: O(log(n)) amortized
+func (uf *ConcurrentUnionFind) Connected(e1, e2 int) bool {
+	for {
+		root1 := uf.Find(e1)
+		root2 := uf.Find(e2)
+		if root1 == root2 {
+			return true
+		}
+		// If root1 is still a root, it was one for the whole time, so the
+		// elements were apart when root2 was found to be a root. Otherwise
+		// the components may have been merged in between: try again.
+		if uf.data[root1].Load() == int64(root1) {
+			return false
+		}
+	}
+}
+
+// Unify merges the components of the two elements.
+//
+// Complexity: O(log(n)) amortized
+func (uf *ConcurrentUnionFind) Unify(e1, e2 int) {
+	for {
+		root1 := int64(uf.Find(e1))
+		root2 := int64(uf.Find(e2))
+		if root1 == root2 {
+			return
+		}
+		if root1 > root2 {
+			root1, root2 = root2, root1
+		}
+		// The link fails if root1 has stopped being a root in the meantime.
+		if uf.data[root1].CompareAndSwap(root1, root2) {
+			uf.components.Add(-1)
+			return
+		}
+	}
+}
+
+// find walks up to the root of `element`, making every other element of the
+// path point to its grandparent.
+func (uf *ConcurrentUnionFind) find(element int64) int64 {
+	for {
+		parent := uf.data[element].Load()
+		if parent == element {
+			return element
+		}
+		grandparent := uf.data[parent].Load()
+		if grandparent != parent {
+			// A failed swap means that another goroutine has already moved
+			// the element closer to the root.
+			uf.data[element].CompareAndSwap(parent, grandparent)
+		}
+		element = grandparent
+	}
+}
+
+func (uf *ConcurrentUnionFind) String() string {
+	res := "{ "
+	for k := range uf.data {
+		res += fmt.Sprintf("%d->%d ", k, uf.data[k].Load())
+	}
+	return res + "}"
+}
@@ -0,0 +1,82 @@
+package unionfind
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestConcurrent(t *testing.T) {
+	uf := NewConcurrent(8)
+
+	uf.Unify(0, 1)
+	uf.Unify(2, 3)
+	uf.Unify(1, 3)
+	uf.Unify(1, 3)
+
+	if !uf.Connected(0, 2) {
+		t.Errorf("union not working")
+	}
+	if uf.Connected(0, 4) {
+		t.Errorf("elements that were never unified should not be connected")
+	}
+	if r1, r2 := uf.Find(0), uf.Find(3); r1 != r2 {
+		t.Errorf("wrong root: got %d want %d", r1, r2)
+	}
+	if c := uf.Components(); c != 5 {
+		t.Errorf("wrong number of components: got %d want %d", c, 5)
+	}
+}
+
+func TestConcurrentManyGoroutines(t *testing.T) {
+	const (
+		n          = 1000
+		goroutines = 16
+		unions     = 2000
+	)
+
+	// Every goroutine applies its own random unions while querying, then the
+	// result is compared with a sequential UnionFind given all the unions.
+	pairs := make([][][2]int, goroutines)
+	r := rand.New(rand.NewSource(44))
+	for g := range pairs {
+		pairs[g] = make([][2]int, unions)
+		for i := range pairs[g] {
+			pairs[g][i] = [2]int{r.Intn(n), r.Intn(n)}
+		}
+	}
+
+	uf := NewConcurrent(n)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(pairs [][2]int) {
+			defer wg.Done()
+			for _, p := range pairs {
+				uf.Unify(p[0], p[1])
+				// Once unified, two elements can never be apart again.
+				if !uf.Connected(p[0], p[1]) {
+					t.Errorf("elements %d and %d should be connected", p[0], p[1])
+				}
+				uf.Find(p[1])
+			}
+		}(pairs[g])
+	}
+	wg.Wait()
+
+	want := NewWithSize(n)
+	for _, ps := range pairs {
+		for _, p := range ps {
+			want.Unify(p[0], p[1])
+		}
+	}
+	if got, exp := uf.Components(), want.Components(); got != exp {
+		t.Errorf("wrong number of components: got %d want %d", got, exp)
+	}
+	for i := 0; i < n; i++ {
+		j := r.Intn(n)
+		if got, exp := uf.Connected(i, j), want.Connected(i, j); got != exp {
+			t.Errorf("wrong connection between %d and %d: got %t want %t", i, j, got, exp)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package unionfind
+
+import "fmt"
+
+// KeyedUnionFind is a UnionFind whose elements are arbitrary comparable keys
+// instead of dense integers. Keys are registered automatically the first
+// time they are used.
+type KeyedUnionFind[K comparable] struct {
+	uf   UnionFind
+	ids  map[K]int
+	keys []K
+}
+
+// NewKeyed returns a new, empty, KeyedUnionFind instance.
+func NewKeyed[K comparable]() KeyedUnionFind[K] {
+	return KeyedUnionFind[K]{
+		uf:   NewWithSize(0),
+		ids:  make(map[K]int),
+		keys: make([]K, 0),
+	}
+}
+
+// Size returns the number of keys that are into the KeyedUnionFind.
+//
+// Complexity: O(1)
+func (kuf *KeyedUnionFind[K]) Size() int {
+	return kuf.uf.Size()
+}
+
+// Components returns the number of components (or groups) that the
+// KeyedUnionFind has.
+//
+// Complexity: O(1)
+func (kuf *KeyedUnionFind[K]) Components() int {
+	return kuf.uf.Components()
+}
+
+// Contains returns whether the `key` has already been registered or not.
+//
+// Complexity: O(1)
+func (kuf *KeyedUnionFind[K]) Contains(key K) bool {
+	_, ok := kuf.ids[key]
+	return ok
+}
+
+// Add registers the `key` in a component of its own, if it is not already
+// present.
+//
+// Complexity: O(1) amortized
+func (kuf *KeyedUnionFind[K]) Add(key K) {
+	kuf.id(key)
+}
+
+// Find finds to which component/group the requested `key` belongs to and
+// returns the key that represents it.
+//
+// Complexity: O(α(n))
+func (kuf *KeyedUnionFind[K]) Find(key K) K {
+	return kuf.keys[kuf.uf.Find(kuf.id(key))]
+}
+
+// Connected returns whether two keys belongs to the same component or not.
+//
+// Complexity: O(α(n))
+func (kuf *KeyedUnionFind[K]) Connected(k1, k2 K) bool {
+	return kuf.uf.Connected(kuf.id(k1), kuf.id(k2))
+}
+
+// ComponentSize returns the number of keys are in the same component as
+// `key`.
+//
+// Complexity: O(α(n))
+func (kuf *KeyedUnionFind[K]) ComponentSize(key K) int {
+	return kuf.uf.ComponentSize(kuf.id(key))
+}
+
+// Unify performs the union operation that merges the components of the two
+// keys into one.
+//
+// Complexity: O(α(n))
+func (kuf *KeyedUnionFind[K]) Unify(k1, k2 K) {
+	kuf.uf.Unify(kuf.id(k1), kuf.id(k2))
+}
+
+// id returns the element of the underlying UnionFind that corresponds to
+// `key`, registering the key if needed.
+func (kuf *KeyedUnionFind[K]) id(key K) int {
+	if id, ok := kuf.ids[key]; ok {
+		return id
+	}
+	id := kuf.uf.MakeSet()
+	kuf.ids[key] = id
+	kuf.keys = append(kuf.keys, key)
+	return id
+}
+
+func (kuf KeyedUnionFind[K]) String() string {
+	res := "{ "
+	for k, v := range kuf.uf.data {
+		res += fmt.Sprintf("%v->%v ", kuf.keys[k], kuf.keys[v])
+	}
+	return res + "}"
+}
@@ -0,0 +1,45 @@
+package unionfind
+
+import "testing"
+
+func TestKeyedUnify(t *testing.T) {
+	kuf := NewKeyed[string]()
+
+	kuf.Add("alice@example.com")
+	kuf.Unify("alice@example.com", "alice@work.example.com")
+	kuf.Unify("bob@example.com", "robert@example.com")
+	kuf.Unify("alice@work.example.com", "a.smith@example.com")
+
+	if s := kuf.Size(); s != 5 {
+		t.Errorf("wrong size: got %d want %d", s, 5)
+	}
+	if c := kuf.Components(); c != 2 {
+		t.Errorf("wrong number of components: got %d want %d", c, 2)
+	}
+	if !kuf.Connected("alice@example.com", "a.smith@example.com") {
+		t.Errorf("union not working")
+	}
+	if kuf.Connected("alice@example.com", "bob@example.com") {
+		t.Errorf("keys `%s` and `%s` should not be connected", "alice@example.com", "bob@example.com")
+	}
+	if s := kuf.ComponentSize("a.smith@example.com"); s != 3 {
+		t.Errorf("wrong component size: got %d want %d", s, 3)
+	}
+	if r := kuf.Find("a.smith@example.com"); r != "alice@example.com" {
+		t.Errorf("wrong root: got %s want %s", r, "alice@example.com")
+	}
+}
+
+func TestKeyedFindRegisters(t *testing.T) {
+	kuf := NewKeyed[string]()
+
+	if kuf.Contains("host-1") {
+		t.Errorf("the key `%s` has not been registered yet", "host-1")
+	}
+	if r := kuf.Find("host-1"); r != "host-1" {
+		t.Errorf("wrong root: got %s want %s", r, "host-1")
+	}
+	if !kuf.Contains("host-1") || kuf.Components() != 1 {
+		t.Errorf("the key `%s` should have been registered on first use", "host-1")
+	}
+}
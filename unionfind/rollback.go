@@ -0,0 +1,151 @@
+package unionfind
+
+import "fmt"
+
+// RollbackUnionFind is a UnionFind whose unions can be undone, in reverse
+// order, back to a previous checkpoint. This is useful for backtracking and
+// for offline dynamic connectivity.
+//
+// Path compression would make undoing a union impossible in constant time,
+// so this variant relies on union by size only, that alone keeps every tree
+// with logarithmic height.
+type RollbackUnionFind struct {
+	data       []int
+	size       []int
+	components int
+	// history holds, for every union, the root that has been attached to the
+	// other one.
+	history []int
+}
+
+// NewRollback returns a new RollbackUnionFind instance with the specified
+// `size` as number of elements.
+//
+// Complexity: O(n)
+func NewRollback(size int) RollbackUnionFind {
+	if size < 0 {
+		panic("size must not be a negative number")
+	}
+
+	data := make([]int, size)
+	sz := make([]int, size)
+
+	for i := 0; i < size; i++ {
+		data[i] = i
+		sz[i] = 1
+	}
+
+	return RollbackUnionFind{
+		data:       data,
+		size:       sz,
+		components: size,
+		history:    make([]int, 0),
+	}
+}
+
+// Size returns the number of elements that are into the RollbackUnionFind.
+//
+// Complexity: O(1)
+func (uf *RollbackUnionFind) Size() int {
+	return len(uf.data)
+}
+
+// Components returns the number of components (or groups) that the
+// RollbackUnionFind has.
+//
+// Complexity: O(1)
+func (uf *RollbackUnionFind) Components() int {
+	return uf.components
+}
+
+// Find finds to which component/group the requested `element` belongs to and
+// returns its root.
+//
+// Complexity: O(log(n))
+func (uf *RollbackUnionFind) Find(element int) int {
+	if element < 0 || element >= uf.Size() {
+		panic("cannot exists such element inside this UnionFind")
+	}
+
+	root := element
+	for root != uf.data[root] {
+		root = uf.data[root]
+	}
+	return root
+}
+
+// Connected returns whether two elements belongs to the same component or
+// not.
+//
+// Complexity: O(log(n))
+func (uf *RollbackUnionFind) Connected(e1, e2 int) bool {
+	return uf.Find(e1) == uf.Find(e2)
+}
+
+// ComponentSize returns the number of elements are in the same component as
+// `element`.
+//
+// Complexity: O(log(n))
+func (uf *RollbackUnionFind) ComponentSize(element int) int {
+	return uf.size[uf.Find(element)]
+}
+
+// Unify performs the union operation that merges to components into one,
+// making as new component root the root that previously was in the bigger
+// component. The union is recorded so that it can be rolled back.
+//
+// Complexity: O(log(n))
+func (uf *RollbackUnionFind) Unify(e1, e2 int) {
+	root1 := uf.Find(e1)
+	root2 := uf.Find(e2)
+
+	if root1 == root2 {
+		return
+	}
+
+	if uf.size[root1] < uf.size[root2] {
+		root1, root2 = root2, root1
+	}
+	uf.size[root1] += uf.size[root2]
+	uf.data[root2] = root1
+	uf.history = append(uf.history, root2)
+
+	uf.components--
+}
+
+// Checkpoint returns a checkpoint of the current state, that can be later
+// restored with Rollback.
+//
+// Complexity: O(1)
+func (uf *RollbackUnionFind) Checkpoint() int {
+	return len(uf.history)
+}
+
+// Rollback undoes, from the most recent one, all the unions performed after
+// the specified `checkpoint`.
+//
+// Complexity: O(1) for every union undone
+func (uf *RollbackUnionFind) Rollback(checkpoint int) error {
+	if checkpoint < 0 || checkpoint > len(uf.history) {
+		return fmt.Errorf("cannot rollback to checkpoint %d not in history", checkpoint)
+	}
+
+	for len(uf.history) > checkpoint {
+		child := uf.history[len(uf.history)-1]
+		uf.history = uf.history[:len(uf.history)-1]
+
+		root := uf.data[child]
+		uf.size[root] -= uf.size[child]
+		uf.data[child] = child
+		uf.components++
+	}
+	return nil
+}
+
+func (uf RollbackUnionFind) String() string {
+	res := "{ "
+	for k, v := range uf.data {
+		res += fmt.Sprintf("%d->%d ", k, v)
+	}
+	return res + "}"
+}
@@ -0,0 +1,83 @@
+package unionfind
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRollback(t *testing.T) {
+	uf := NewRollback(8)
+
+	uf.Unify(0, 1)
+	cp := uf.Checkpoint()
+	uf.Unify(2, 3)
+	uf.Unify(1, 3)
+	uf.Unify(1, 3)
+
+	if !uf.Connected(0, 2) {
+		t.Errorf("union not working")
+	}
+	if err := uf.Rollback(cp); err != nil {
+		t.Errorf("error while rolling back: %v", err)
+	}
+	if uf.Connected(0, 2) || uf.Connected(2, 3) {
+		t.Errorf("unions after the checkpoint should have been undone")
+	}
+	if !uf.Connected(0, 1) {
+		t.Errorf("unions before the checkpoint should have been kept")
+	}
+	if c := uf.Components(); c != 7 {
+		t.Errorf("wrong number of components: got %d want %d", c, 7)
+	}
+	if s := uf.ComponentSize(1); s != 2 {
+		t.Errorf("wrong component size: got %d want %d", s, 2)
+	}
+	if err := uf.Rollback(cp + 1); err == nil {
+		t.Errorf("rollback to a future checkpoint should have returned an error")
+	}
+}
+
+func TestRollbackAgainstUnionFind(t *testing.T) {
+	r := rand.New(rand.NewSource(41))
+	const n = 50
+	uf := NewRollback(n)
+
+	// Apply random batches of unions, then check them against a UnionFind
+	// rebuilt from scratch, and randomly undo some batches.
+	var batches [][][2]int
+	var checkpoints []int
+	for step := 0; step < 200; step++ {
+		if len(batches) > 0 && r.Intn(3) == 0 {
+			uf.Rollback(checkpoints[len(checkpoints)-1])
+			batches = batches[:len(batches)-1]
+			checkpoints = checkpoints[:len(checkpoints)-1]
+		} else {
+			checkpoints = append(checkpoints, uf.Checkpoint())
+			var batch [][2]int
+			for i := r.Intn(5); i > 0; i-- {
+				pair := [2]int{r.Intn(n), r.Intn(n)}
+				uf.Unify(pair[0], pair[1])
+				batch = append(batch, pair)
+			}
+			batches = append(batches, batch)
+		}
+
+		want := NewWithSize(n)
+		for _, batch := range batches {
+			for _, pair := range batch {
+				want.Unify(pair[0], pair[1])
+			}
+		}
+		if uf.Components() != want.Components() {
+			t.Fatalf("step %d: wrong number of components: got %d want %d", step, uf.Components(), want.Components())
+		}
+		for e := 0; e < n; e++ {
+			if got, w := uf.ComponentSize(e), want.ComponentSize(e); got != w {
+				t.Fatalf("step %d: wrong component size of %d: got %d want %d", step, e, got, w)
+			}
+			if got, w := uf.Connected(0, e), want.Connected(0, e); got != w {
+				t.Fatalf("step %d: wrong connectivity of %d: got %t want %t", step, e, got, w)
+			}
+		}
+	}
+}
@@ -5,8 +5,11 @@ import "fmt"
 // UnionFind is a data structure that keeps track of elements which are split
 // into one or more disjoint sets.
 type UnionFind struct {
-	data       []int
-	size       []int
+	data []int
+	size []int
+	// next links every element to another one of its component, so that the
+	// members of each component form a circular linked list.
+	next       []int
 	components int
 }
 
@@ -22,25 +25,29 @@ func New() UnionFind {
 }
 
 // NewWithSize returns a new UnionFind instance with the specified `size` as
-// number of elements.
+// number of elements. More elements can be added later with MakeSet and
+// Grow.
 //
 // Complexity: O(n)
 func NewWithSize(size int) UnionFind {
-	if size <= 0 {
-		panic("size must be a positive number")
+	if size < 0 {
+		panic("size must not be a negative number")
 	}
 
 	data := make([]int, size)
 	sz := make([]int, size)
+	next := make([]int, size)
 
 	for i := 0; i < size; i++ {
 		data[i] = i
 		sz[i] = 1
+		next[i] = i
 	}
 
 	return UnionFind{
 		data:       data,
 		size:       sz,
+		next:       next,
 		components: size,
 	}
 }
@@ -52,6 +59,32 @@ func (uf *UnionFind) Size() int {
 	return len(uf.data)
 }
 
+// MakeSet adds a new element to the UnionFind, in a component of its own,
+// and returns it.
+//
+// Complexity: O(1) amortized
+func (uf *UnionFind) MakeSet() int {
+	element := uf.Size()
+	uf.data = append(uf.data, element)
+	uf.size = append(uf.size, 1)
+	uf.next = append(uf.next, element)
+	uf.components++
+	return element
+}
+
+// Grow adds `n` new elements to the UnionFind, each in a component of its
+// own. The new elements are the ones from the previous Size() onwards.
+//
+// Complexity: O(n)
+func (uf *UnionFind) Grow(n int) {
+	if n < 0 {
+		panic("cannot grow by a negative number of elements")
+	}
+	for i := 0; i < n; i++ {
+		uf.MakeSet()
+	}
+}
+
 // Components returns the number of components (or groups) that the UnionFind
 // has.
 //
@@ -66,7 +99,7 @@ func (uf *UnionFind) Components() int {
 //
 // Complexity: O(α(n))
 func (uf *UnionFind) Find(element int) int {
-	if element > uf.Size() {
+	if element < 0 || element >= uf.Size() {
 		panic("cannot exists such element inside this UnionFind")
 	}
 
@@ -96,6 +129,36 @@ func (uf *UnionFind) ComponentSize(element int) int {
 	return uf.size[uf.Find(element)]
 }
 
+// Members returns all the elements that are in the same component as
+// `element`, including itself.
+//
+// Complexity: O(component size)
+func (uf *UnionFind) Members(element int) []int {
+	if element < 0 || element >= uf.Size() {
+		panic("cannot exists such element inside this UnionFind")
+	}
+
+	res := []int{element}
+	for e := uf.next[element]; e != element; e = uf.next[e] {
+		res = append(res, e)
+	}
+	return res
+}
+
+// Groups returns the members of every component, keyed by the root of the
+// component.
+//
+// Complexity: O(n*α(n))
+func (uf *UnionFind) Groups() map[int][]int {
+	res := make(map[int][]int, uf.Components())
+	for e := 0; e < uf.Size(); e++ {
+		if uf.Find(e) == e {
+			res[e] = uf.Members(e)
+		}
+	}
+	return res
+}
+
 // Unify performs the union operation that merges to components into one,
 // making as new component root the root that previously was in the bigger
 // component.
@@ -117,6 +180,10 @@ func (uf *UnionFind) Unify(e1, e2 int) {
 		uf.data[root2] = root1
 	}
 
+	// Exchanging the successors of two elements of different circular lists
+	// splices them into a single one.
+	uf.next[root1], uf.next[root2] = uf.next[root2], uf.next[root1]
+
 	uf.components--
 }
 
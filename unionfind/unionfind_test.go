@@ -1,6 +1,9 @@
 package unionfind
 
-import "testing"
+import (
+	"sort"
+	"testing"
+)
 
 func TestFind(t *testing.T) {
 	uf := New()
@@ -48,3 +51,71 @@ func TestUnify(t *testing.T) {
 	}
 	t.Log(uf) // Here should be { 0->0 1->2 2->2 3->2 4->2 5->5 6->6 7->7 }.
 }
+
+func TestMakeSet(t *testing.T) {
+	uf := NewWithSize(0)
+
+	for i := 0; i < 4; i++ {
+		if e := uf.MakeSet(); e != i {
+			t.Errorf("wrong new element: got %d want %d", e, i)
+		}
+	}
+	uf.Unify(0, 1)
+	uf.Grow(3)
+	uf.Unify(1, 6)
+
+	if s := uf.Size(); s != 7 {
+		t.Errorf("wrong size: got %d want %d", s, 7)
+	}
+	if c := uf.Components(); c != 5 {
+		t.Errorf("wrong number of components: got %d want %d", c, 5)
+	}
+	if s := uf.ComponentSize(6); s != 3 {
+		t.Errorf("wrong component size: got %d want %d", s, 3)
+	}
+	if uf.Connected(5, 6) {
+		t.Errorf("elements `%d` and `%d` should not be connected", 5, 6)
+	}
+}
+
+func TestMembers(t *testing.T) {
+	uf := New()
+
+	uf.Unify(2, 3)
+	uf.Unify(1, 4)
+	uf.Unify(2, 4)
+	uf.Grow(1)
+	uf.Unify(8, 5)
+
+	members := uf.Members(4)
+	sort.Ints(members)
+	want := []int{1, 2, 3, 4}
+	if len(members) != len(want) {
+		t.Fatalf("wrong members: got %v want %v", members, want)
+	}
+	for i := range want {
+		if members[i] != want[i] {
+			t.Errorf("wrong members: got %v want %v", members, want)
+		}
+	}
+
+	groups := uf.Groups()
+	if len(groups) != uf.Components() {
+		t.Errorf("wrong number of groups: got %d want %d", len(groups), uf.Components())
+	}
+	total := 0
+	for root, g := range groups {
+		if s := uf.ComponentSize(root); s != len(g) {
+			t.Errorf("wrong group size of %d: got %d want %d", root, len(g), s)
+		}
+		for _, e := range g {
+			if uf.Find(e) != root {
+				t.Errorf("element `%d` is not in the group of `%d`", e, root)
+			}
+		}
+		total += len(g)
+	}
+	if total != uf.Size() {
+		t.Errorf("wrong number of grouped elements: got %d want %d", total, uf.Size())
+	}
+}
@@ -0,0 +1,182 @@
+package unionfind
+
+import "fmt"
+
+// WeightedUnionFind is a UnionFind that also keeps track of the difference
+// between the (unknown) values of the elements of a component, given as
+// constraints like "a - b = d". Every element stores the offset from its
+// parent, which is updated by path compression.
+//
+// Differences can be taken modulo some number, for instance 2 for parity
+// constraints like "a and b are in different teams".
+type WeightedUnionFind struct {
+	data []int
+	size []int
+	// offset is the difference between the value of every element and the
+	// value of its parent.
+	offset     []int
+	components int
+	mod        int
+}
+
+// NewWeighted returns a new WeightedUnionFind instance with the specified
+// `size` as number of elements, whose differences are plain integers.
+//
+// Complexity: O(n)
+func NewWeighted(size int) WeightedUnionFind {
+	return NewWeightedMod(size, 0)
+}
+
+// NewWeightedMod returns a new WeightedUnionFind instance with the specified
+// `size` as number of elements, whose differences are taken modulo `mod`. A
+// `mod` of zero means no modulo.
+//
+// Complexity: O(n)
+func NewWeightedMod(size, mod int) WeightedUnionFind {
+	if size < 0 {
+		panic("size must not be a negative number")
+	}
+	if mod < 0 {
+		panic("mod must not be a negative number")
+	}
+
+	data := make([]int, size)
+	sz := make([]int, size)
+
+	for i := 0; i < size; i++ {
+		data[i] = i
+		sz[i] = 1
+	}
+
+	return WeightedUnionFind{
+		data:       data,
+		size:       sz,
+		offset:     make([]int, size),
+		components: size,
+		mod:        mod,
+	}
+}
+
+// Size returns the number of elements that are into the WeightedUnionFind.
+//
+// Complexity: O(1)
+func (uf *WeightedUnionFind) Size() int {
+	return len(uf.data)
+}
+
+// Components returns the number of components (or groups) that the
+// WeightedUnionFind has.
+//
+// Complexity: O(1)
+func (uf *WeightedUnionFind) Components() int {
+	return uf.components
+}
+
+// Find finds to which component/group the requested `element` belongs to and
+// returns its root.
+// This method also applies Path Compression.
+//
+// Complexity: O(α(n))
+func (uf *WeightedUnionFind) Find(element int) int {
+	if element < 0 || element >= uf.Size() {
+		panic("cannot exists such element inside this UnionFind")
+	}
+
+	path := []int{}
+	root := element
+	for root != uf.data[root] {
+		path = append(path, root)
+		root = uf.data[root]
+	}
+
+	uf.compressPath(path, root)
+
+	return root
+}
+
+// Connected returns whether two elements belongs to the same component or
+// not.
+//
+// Complexity: O(α(n))
+func (uf *WeightedUnionFind) Connected(e1, e2 int) bool {
+	return uf.Find(e1) == uf.Find(e2)
+}
+
+// ComponentSize returns the number of elements are in the same component as
+// `element`.
+//
+// Complexity: O(α(n))
+func (uf *WeightedUnionFind) ComponentSize(element int) int {
+	return uf.size[uf.Find(element)]
+}
+
+// Diff returns the difference between the values of `e1` and `e2`, if it can
+// be derived from the constraints, that is if they are connected.
+//
+// Complexity: O(α(n))
+func (uf *WeightedUnionFind) Diff(e1, e2 int) (int, bool) {
+	if uf.Find(e1) != uf.Find(e2) {
+		return 0, false
+	}
+	return uf.normalize(uf.offset[e1] - uf.offset[e2]), true
+}
+
+// UnifyWithDiff adds the constraint that the value of `e1` minus the value
+// of `e2` is `diff`, merging their components. It returns an error if the
+// constraint contradicts the ones already added.
+//
+// Complexity: O(α(n))
+func (uf *WeightedUnionFind) UnifyWithDiff(e1, e2, diff int) error {
+	root1 := uf.Find(e1)
+	root2 := uf.Find(e2)
+	diff = uf.normalize(diff)
+
+	// After Find, the offsets are relative to the roots.
+	if root1 == root2 {
+		if got := uf.normalize(uf.offset[e1] - uf.offset[e2]); got != diff {
+			return fmt.Errorf("cannot set %d - %d = %d: it is already %d", e1, e2, diff, got)
+		}
+		return nil
+	}
+
+	// The offset between the roots follows from
+	// e1 - e2 = (e1 - root1) + (root1 - root2) - (e2 - root2).
+	rootDiff := diff - uf.offset[e1] + uf.offset[e2]
+	if uf.size[root1] < uf.size[root2] {
+		uf.size[root2] += uf.size[root1]
+		uf.data[root1] = root2
+		uf.offset[root1] = uf.normalize(rootDiff)
+	} else {
+		uf.size[root1] += uf.size[root2]
+		uf.data[root2] = root1
+		uf.offset[root2] = uf.normalize(-rootDiff)
+	}
+
+	uf.components--
+	return nil
+}
+
+// compressPath makes every element of `path`, ordered from the bottom up,
+// point directly to `root`, summing up the offsets along the way.
+func (uf *WeightedUnionFind) compressPath(path []int, root int) {
+	// The last element of the path is already a child of the root.
+	for i := len(path) - 2; i >= 0; i-- {
+		uf.offset[path[i]] = uf.normalize(uf.offset[path[i]] + uf.offset[path[i+1]])
+		uf.data[path[i]] = root
+	}
+}
+
+func (uf *WeightedUnionFind) normalize(x int) int {
+	if uf.mod == 0 {
+		return x
+	}
+	return ((x % uf.mod) + uf.mod) % uf.mod
+}
+
+func (uf WeightedUnionFind) String() string {
+	res := "{ "
+	for k, v := range uf.data {
+		res += fmt.Sprintf("%d->%d(%+d) ", k, v, uf.offset[k])
+	}
+	return res + "}"
+}
@@ -0,0 +1,79 @@
+package unionfind
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestUnifyWithDiff(t *testing.T) {
+	uf := NewWeighted(6)
+
+	// x0 - x1 = 3, x1 - x2 = 4, x3 - x2 = 10.
+	if err := uf.UnifyWithDiff(0, 1, 3); err != nil {
+		t.Errorf("error while adding a valid constraint: %v", err)
+	}
+	uf.UnifyWithDiff(1, 2, 4)
+	uf.UnifyWithDiff(3, 2, 10)
+
+	if d, ok := uf.Diff(0, 3); !ok || d != -3 {
+		t.Errorf("wrong difference: got %d want %d", d, -3)
+	}
+	if d, ok := uf.Diff(2, 0); !ok || d != -7 {
+		t.Errorf("wrong difference: got %d want %d", d, -7)
+	}
+	if _, ok := uf.Diff(0, 4); ok {
+		t.Errorf("the difference between unconnected elements should be unknown")
+	}
+	if err := uf.UnifyWithDiff(0, 3, -3); err != nil {
+		t.Errorf("error while adding a redundant constraint: %v", err)
+	}
+	if err := uf.UnifyWithDiff(0, 3, 5); err == nil {
+		t.Errorf("a contradicting constraint should have returned an error")
+	}
+	if c := uf.Components(); c != 3 {
+		t.Errorf("wrong number of components: got %d want %d", c, 3)
+	}
+}
+
+func TestUnifyWithDiffRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(43))
+	const n = 200
+	values := make([]int, n)
+	for i := range values {
+		values[i] = r.Intn(1000) - 500
+	}
+
+	// Constraints taken from hidden values never contradict each other, and
+	// every derived difference must match the hidden values.
+	uf := NewWeighted(n)
+	for i := 0; i < 300; i++ {
+		a, b := r.Intn(n), r.Intn(n)
+		if err := uf.UnifyWithDiff(a, b, values[a]-values[b]); err != nil {
+			t.Fatalf("error while adding a consistent constraint: %v", err)
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		a, b := r.Intn(n), r.Intn(n)
+		if d, ok := uf.Diff(a, b); ok && d != values[a]-values[b] {
+			t.Fatalf("wrong difference between %d and %d: got %d want %d", a, b, d, values[a]-values[b])
+		}
+	}
+}
+
+func TestWeightedParity(t *testing.T) {
+	uf := NewWeightedMod(4, 2)
+
+	// 0 and 1 are in different teams, as 1 and 2: so 0 and 2 are together.
+	uf.UnifyWithDiff(0, 1, 1)
+	uf.UnifyWithDiff(1, 2, 1)
+
+	if d, _ := uf.Diff(0, 2); d != 0 {
+		t.Errorf("wrong parity: got %d want %d", d, 0)
+	}
+	if err := uf.UnifyWithDiff(2, 1, 1); err != nil {
+		t.Errorf("error while adding a consistent parity constraint: %v", err)
+	}
+	if err := uf.UnifyWithDiff(0, 2, 1); err == nil {
+		t.Errorf("a contradicting parity constraint should have returned an error")
+	}
+}